@@ -0,0 +1,70 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The desktop is always executed directly through os/exec, never through a shell,
+// so args reach it verbatim and shell metacharacters in them have no effect.
+// What the desktop itself does with a flag is a different matter: hosts that
+// forward user input into args should restrict the flags with an allowlist.
+
+// LaunchWithAllowedFlags is Launch that first checks args against an allowlist
+// of flags, see ValidateArgs.
+func LaunchWithAllowedFlags(ctx context.Context, allowed []string, args ...string) (*Process, error) {
+	return LaunchWithOptions(ctx, LaunchOptions{Args: args, AllowedFlags: allowed})
+}
+
+// ValidateArgs returns an error for the first argument that starts with a dash
+// and names a flag missing from allowed. Flags are compared by name: leading
+// dashes and an "=value" suffix are ignored, so "--port=80" matches an allowed
+// "port" or "--port". Arguments without a leading dash are positional and not
+// checked. "--" is checked like any other flag rather than ending the flags,
+// since the desktop may not treat it as a terminator.
+func ValidateArgs(allowed []string, args []string) error {
+	names := make([]string, 0, len(allowed))
+	for _, flag := range allowed {
+		names = append(names, flagName(flag))
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			continue
+		}
+		if !slices.Contains(names, flagName(arg)) {
+			return fmt.Errorf("embedded: argument %q is not an allowed flag", arg)
+		}
+	}
+	return nil
+}
+
+func flagName(arg string) string {
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return name
+}
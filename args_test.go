@@ -0,0 +1,95 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	helpers["echo-args"] = echoArgs
+}
+
+// echoArgs prints its args, one per line.
+func echoArgs() int {
+	for _, arg := range os.Args[1:] {
+		fmt.Println(arg)
+	}
+	return 0
+}
+
+func TestValidateArgs(t *testing.T) {
+	allowed := []string{"--port", "verbose", "-v"}
+	tests := []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{"--port=80", "--verbose", "-v", "positional", "-"}, true},
+		{[]string{"--port", "80"}, true},
+		{[]string{"--exec=/bin/sh"}, false},
+		{[]string{"--port=80", "-exec", "rm -rf /"}, false},
+		{[]string{"--", "--exec=/bin/sh"}, false},
+	}
+	for _, tt := range tests {
+		err := ValidateArgs(allowed, tt.args)
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidateArgs(%q) = %v, want ok %v", tt.args, err, tt.ok)
+		}
+	}
+}
+
+func TestLaunchRejectsDisallowedFlag(t *testing.T) {
+	m := newTestManager(t)
+	_, err := m.LaunchWithOptions(context.Background(), LaunchOptions{
+		Args:         []string{"--port=80", "--exec=/bin/sh -c 'id'"},
+		AllowedFlags: []string{"port"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"--exec=/bin/sh -c 'id'" is not an allowed flag`) {
+		t.Fatalf("err = %v, want the --exec flag rejected", err)
+	}
+	// rejected before anything was extracted or started
+	if s := m.Stats(); s.Extractions != 0 || s.Launches != 0 || s.LaunchFailures != 0 {
+		t.Fatalf("stats %+v, want nothing extracted or started", s)
+	}
+}
+
+func TestLaunchPassesArgsVerbatim(t *testing.T) {
+	m := helperManager(t)
+	marker := filepath.Join(t.TempDir(), "injected")
+	args := []string{"$(touch " + marker + ")", "`touch " + marker + "`", "a; touch " + marker, "*", "quoted \"arg\""}
+
+	out := runHelper(t, m, helperOptions("echo-args", args...))
+	if want := strings.Join(args, "\n") + "\n"; strings.ReplaceAll(out, "\r\n", "\n") != want {
+		t.Fatalf("helper got %q, want %q", out, want)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("an argument was interpreted by a shell")
+	}
+}
@@ -0,0 +1,131 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// staleEphemeralAge is how old an ephemeral temp dir has to be for Cleanup to
+// treat it as a remnant of a killed launcher.
+const staleEphemeralAge = 24 * time.Hour
+
+var (
+	exitCleanupMx      sync.Mutex
+	exitCleanupEnabled bool
+	exitCleanupDirs    = make(map[string]struct{})
+
+	// managedLaunches counts active LaunchManaged handlers, which run the exit
+	// cleanup themselves before exiting.
+	managedLaunches atomic.Int32
+)
+
+// RegisterCleanupOnExit makes LaunchEphemeral record its temp dirs and installs
+// a SIGINT/SIGTERM handler that removes the recorded dirs and exits with status
+// 128 plus the signal number. While a LaunchManaged handler is active, it stops
+// the desktop and runs the same cleanup instead. Calling it again is a no-op.
+//
+// The cleanup is best effort: os.Exit, a panic or a hard kill (SIGKILL) can't be
+// intercepted, and Go runs no finalizers at exit. Temp dirs left behind that way
+// are removed by the next Cleanup call once they are older than a day.
+func RegisterCleanupOnExit() {
+	exitCleanupMx.Lock()
+	defer exitCleanupMx.Unlock()
+	if exitCleanupEnabled {
+		return
+	}
+	exitCleanupEnabled = true
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			if managedLaunches.Load() > 0 {
+				continue
+			}
+			runExitCleanup()
+			os.Exit(exitStatus(sig))
+		}
+	}()
+}
+
+// UnregisterCleanup excludes an ephemeral extraction from the exit cleanup, for
+// binaries that are meant to outlive the launcher. path is the extracted binary
+// or its temp dir.
+func UnregisterCleanup(path string) {
+	exitCleanupMx.Lock()
+	defer exitCleanupMx.Unlock()
+	delete(exitCleanupDirs, path)
+	delete(exitCleanupDirs, filepath.Dir(path))
+}
+
+func trackExitCleanup(dir string) {
+	exitCleanupMx.Lock()
+	defer exitCleanupMx.Unlock()
+	if exitCleanupEnabled {
+		exitCleanupDirs[dir] = struct{}{}
+	}
+}
+
+func untrackExitCleanup(dir string) {
+	exitCleanupMx.Lock()
+	defer exitCleanupMx.Unlock()
+	delete(exitCleanupDirs, dir)
+}
+
+func runExitCleanup() {
+	exitCleanupMx.Lock()
+	defer exitCleanupMx.Unlock()
+	for dir := range exitCleanupDirs {
+		_ = os.RemoveAll(dir)
+		delete(exitCleanupDirs, dir)
+	}
+}
+
+// removeStaleEphemeral removes LaunchEphemeral temp dirs that are older than
+// staleEphemeralAge. Dirs that don't hold the desktop binary are left alone, and
+// failures are ignored: on Windows a dir of a still running desktop is locked.
+func removeStaleEphemeral() {
+	tmps, err := filepath.Glob(filepath.Join(os.TempDir(), cacheDirName+"-*"))
+	if err != nil {
+		return
+	}
+	for _, dir := range tmps {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() || time.Since(info.ModTime()) < staleEphemeralAge {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, binaryName)); err != nil {
+			continue
+		}
+		_ = os.RemoveAll(dir)
+	}
+}
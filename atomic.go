@@ -0,0 +1,138 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// binaryMode is the owner-only executable mode of the extracted binary.
+	// Windows decides executability by the .exe extension and ignores it.
+	binaryMode fs.FileMode = 0o700
+	// fileMode is the mode of the files written next to the binary.
+	fileMode fs.FileMode = 0o600
+)
+
+// WriteFileAtomic writes data to path the way this package writes the extracted
+// binary: into a temp file in the same dir, so the final rename stays on one
+// filesystem and is atomic, synced to disk and then renamed over path. Readers
+// see either the old file or the complete new one, never a partial write. On
+// failure the temp file is removed and path is left as it was. perm isn't
+// applied on Windows, where mode bits don't decide executability.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return writeAtomic(path, bytes.NewReader(data), perm)
+}
+
+// writeAtomic is WriteFileAtomic streaming the contents from r.
+func writeAtomic(path string, r io.Reader, perm os.FileMode) error {
+	return writeAtomicVerified(path, r, perm, nil)
+}
+
+// writeAtomicVerified is writeAtomic that calls verify once r is drained and
+// synced, and only renames the file into place if verify returns nil.
+func writeAtomicVerified(path string, r io.Reader, perm os.FileMode, verify func() error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("embedded: create temp file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = copyToTemp(tmp, r); err != nil {
+		return fmt.Errorf("embedded: write %s: %w", filepath.Base(path), err)
+	}
+	if err = setMode(tmp, perm); err != nil {
+		return fmt.Errorf("embedded: chmod %s: %w", filepath.Base(path), err)
+	}
+	// without the sync a crash right after the rename can leave an empty file
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("embedded: sync %s: %w", filepath.Base(path), err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("embedded: close %s: %w", filepath.Base(path), err)
+	}
+	if verify != nil {
+		if err = verify(); err != nil {
+			return err
+		}
+	}
+	return replaceFile(tmp.Name(), path)
+}
+
+// copyToTemp copies the contents into the temp file writeAtomicVerified writes.
+// Tests replace it to inject write failures.
+var copyToTemp = io.Copy
+
+// ErrBinaryBusy is returned on Windows when the extracted binary is in use by a
+// running desktop and can neither be replaced nor moved out of the way.
+var ErrBinaryBusy = errors.New("embedded: desktop binary is in use")
+
+// replaceFile renames tmp over path. On Windows, where a running executable is
+// locked and can't be replaced, the running file is moved aside under a unique
+// name, which it keeps running from, and tmp takes its place. The aside files
+// are removed by Cleanup and Update once they're unused. Elsewhere the rename
+// succeeds while the binary runs, so the fallback is Windows-only, see isBusy.
+func replaceFile(tmp, path string) error {
+	err := os.Rename(tmp, path)
+	if err == nil {
+		return nil
+	}
+	if !isBusy(err, path) {
+		return fmt.Errorf("embedded: rename %s: %w", filepath.Base(path), err)
+	}
+
+	aside := fmt.Sprintf("%s.%d%s", path, time.Now().UnixNano(), asideSuffix)
+	if moveErr := os.Rename(path, aside); moveErr != nil {
+		return fmt.Errorf("%w: replace %s: %w, move aside: %w", ErrBinaryBusy, path, err, moveErr)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Rename(aside, path)
+		return fmt.Errorf("%w: replace %s: %w", ErrBinaryBusy, path, err)
+	}
+	return nil
+}
+
+// asideSuffix ends the names replaceFile moves running binaries to.
+const asideSuffix = ".old"
+
+// isAsideName reports whether name is a binary, stable or versioned, that
+// replaceFile moved aside.
+func isAsideName(name string) bool {
+	stem := strings.TrimSuffix(binaryName, filepath.Ext(binaryName))
+	return strings.HasPrefix(name, stem) && strings.HasSuffix(name, asideSuffix)
+}
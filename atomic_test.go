@@ -0,0 +1,165 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// dirNames returns the names in dir.
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state")
+
+	var tmpDir string
+	t.Cleanup(func() { copyToTemp = io.Copy })
+	copyToTemp = func(dst io.Writer, src io.Reader) (int64, error) {
+		tmpDir = filepath.Dir(dst.(*os.File).Name())
+		return io.Copy(dst, src)
+	}
+
+	for _, data := range [][]byte{[]byte("first"), []byte("second, longer")} {
+		if err := WriteFileAtomic(path, data, fileMode); err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("contents = %q, want %q", got, data)
+		}
+	}
+	if tmpDir != dir {
+		t.Fatalf("temp file written in %s, want the target dir %s", tmpDir, dir)
+	}
+	if names := dirNames(t, dir); len(names) != 1 {
+		t.Fatalf("dir holds %v, want only the written file", names)
+	}
+}
+
+func TestWriteFileAtomicFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state")
+	if err := os.WriteFile(path, []byte("old"), fileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	failWrites(t, 1)
+	err := WriteFileAtomic(path, bytes.Repeat([]byte("x"), 4<<10), fileMode)
+	if !errors.Is(err, errDiskFull) {
+		t.Fatalf("err = %v, want the write error", err)
+	}
+	errVerify := errors.New("mismatch")
+	err = writeAtomicVerified(path, bytes.NewReader([]byte("new")), fileMode, func() error { return errVerify })
+	if !errors.Is(err, errVerify) {
+		t.Fatalf("err = %v, want the verify error", err)
+	}
+
+	if got, _ := os.ReadFile(path); string(got) != "old" {
+		t.Fatalf("contents = %q, want the old file untouched", got)
+	}
+	if names := dirNames(t, dir); len(names) != 1 {
+		t.Fatalf("dir holds %v, want the temp files removed", names)
+	}
+}
+
+func TestReextractOverRunningBinary(t *testing.T) {
+	requireBinary(t)
+	m := newTestManager(t)
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// run a copy of the test binary from the extracted path, as a desktop would
+	f, err := os.Open(testExecutable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeAtomic(path, f, binaryMode)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), helperEnv+"=cat")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	// the running copy no longer matches, so this writes the binary over it
+	again, err := m.ensureExtracted("", true)
+	if err != nil {
+		t.Fatalf("re-extraction over a running binary: %v", err)
+	}
+	if err := verifyFile(again, Checksum()); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bufio.NewScanner(stdout)
+	if _, err := io.WriteString(stdin, "still here\n"); err != nil {
+		t.Fatalf("running child is gone: %v", err)
+	}
+	if !lines.Scan() || lines.Text() != "still here" {
+		t.Fatalf("running child didn't answer: %q, %v", lines.Text(), lines.Err())
+	}
+	stdin.Close()
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("running child: %v", err)
+	}
+}
@@ -3,14 +3,49 @@ package bin
 import (
 	_ "embed"
 	"os"
+	"path/filepath"
+	"sync"
 )
 
+// BinaryPath is the directory the running executable lives in. It is kept for
+// backward compatibility and is empty if the location can't be resolved;
+// use BinaryDir to get the error.
 var BinaryPath string
 
+var (
+	overrideMx sync.RWMutex
+	override   string
+)
+
 func init() {
-	var err error
-	BinaryPath, err = os.Getwd()
-	if err != nil {
-		panic(err)
+	BinaryPath, _ = BinaryDir()
+}
+
+// SetBinaryPath overrides the directory returned by BinaryDir and stored in
+// BinaryPath. An empty path restores the default resolution.
+func SetBinaryPath(path string) {
+	overrideMx.Lock()
+	override = path
+	overrideMx.Unlock()
+
+	BinaryPath, _ = BinaryDir()
+}
+
+// BinaryDir returns the directory the running executable lives in, or the path
+// set by SetBinaryPath. If the executable location can't be resolved it falls
+// back to the working directory, which is what BinaryPath used to hold. The
+// embedded package extracts next to it when the OS has no user cache dir.
+func BinaryDir() (string, error) {
+	overrideMx.RLock()
+	path := override
+	overrideMx.RUnlock()
+	if path != "" {
+		return path, nil
+	}
+
+	exe, err := os.Executable()
+	if err == nil {
+		return filepath.Dir(exe), nil
 	}
+	return os.Getwd()
 }
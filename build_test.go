@@ -0,0 +1,83 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestBuildMatrix cross-compiles the package for the tuples documented in
+// embed-guard.go: the supported ones and an OS without a binary build, a
+// supported OS with an architecture that has no binary fails to compile.
+func TestBuildMatrix(t *testing.T) {
+	if testing.Short() {
+		t.Skip("cross-compiles the package for every tuple")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not available")
+	}
+
+	tests := []struct {
+		goos, goarch string
+		builds       bool
+	}{
+		{"darwin", "amd64", true},
+		{"darwin", "arm64", true},
+		{"linux", "amd64", true},
+		{"windows", "amd64", true},
+		{"freebsd", "amd64", true},
+		{"linux", "arm64", false},
+		{"windows", "arm64", false},
+		{"linux", "386", false},
+	}
+	for _, tags := range []string{"", "warpnet_zstd"} {
+		for _, tt := range tests {
+			name := tt.goos + "/" + tt.goarch
+			if tags != "" {
+				name += "/" + tags
+			}
+			t.Run(name, func(t *testing.T) {
+				cmd := exec.Command(goTool, "build", "-tags", tags, ".")
+				cmd.Env = append(os.Environ(), "GOOS="+tt.goos, "GOARCH="+tt.goarch, "CGO_ENABLED=0")
+				out, err := cmd.CombinedOutput()
+				if tt.builds && err != nil {
+					t.Fatalf("build failed: %v\n%s", err, out)
+				}
+				if !tt.builds {
+					if err == nil {
+						t.Fatal("build succeeded without an embedded binary")
+					}
+					if !strings.Contains(string(out), "embed-guard.go") {
+						t.Fatalf("build failed for another reason than the guard:\n%s", out)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Cleanup removes what EnsureExtracted, Update and ExtractResources left in
+// ExtractDir: the binary and its versions, ContentAddressed dirs, their checksum
+// sidecars, the lock file and pidfile, the bundled resources, remote overrides,
+// binaries moved aside while they were running and temp files of interrupted
+// extractions.
+// The dir itself is removed only if nothing else is left in it, so files this
+// package didn't create are never touched. LaunchEphemeral temp dirs older than
+// a day, left behind by a killed launcher, are removed as well. Cleanup is a
+// no-op if there is nothing to remove.
+func Cleanup() error {
+	return defaultManager.Cleanup()
+}
+
+func cleanupDir(dir string) error {
+	names := []string{
+		binaryName, binaryName + checksumSuffix, lockFileName, pidFileName, remoteName,
+		binaryName + ".link.tmp",
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("embedded: cleanup: %w", err)
+	}
+	for _, e := range entries {
+		switch {
+		case e.IsDir() && isContentDirName(e.Name()):
+			if err := cleanupDir(filepath.Join(dir, e.Name())); err != nil {
+				return err
+			}
+		case isVersionedName(e.Name()):
+			names = append(names, e.Name(), e.Name()+checksumSuffix)
+		case isAsideName(e.Name()):
+			names = append(names, e.Name())
+		}
+	}
+	patterns := []string{
+		binaryName + ".*.tmp",
+		binaryName + checksumSuffix + ".*.tmp",
+		strings.TrimSuffix(binaryName, filepath.Ext(binaryName)) + "-*.tmp", // versions
+	}
+	for _, pattern := range patterns {
+		tmps, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return fmt.Errorf("embedded: cleanup: %w", err)
+		}
+		for _, tmp := range tmps {
+			names = append(names, filepath.Base(tmp))
+		}
+	}
+
+	resources, err := resourceFiles(resourcesFS)
+	if err != nil {
+		return err
+	}
+	for _, name := range resources {
+		names = append(names, filepath.FromSlash(name))
+	}
+
+	for _, name := range names {
+		err := os.Remove(filepath.Join(dir, name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("embedded: cleanup: %w", err)
+		}
+	}
+	removeEmptyParents(dir, resources)
+
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("embedded: cleanup: %w", err)
+	}
+	if len(entries) > 0 {
+		return nil
+	}
+	if err := os.Remove(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("embedded: cleanup: %w", err)
+	}
+	return nil
+}
+
+// removeEmptyParents removes the resource subdirs under dir that are left empty,
+// deepest first. Dirs that still hold other files fail to remove and are kept.
+func removeEmptyParents(dir string, resources []string) {
+	seen := make(map[string]bool)
+	var parents []string
+	for _, name := range resources {
+		for p := path.Dir(name); p != "."; p = path.Dir(p) {
+			if !seen[p] {
+				seen[p] = true
+				parents = append(parents, p)
+			}
+		}
+	}
+	sort.Slice(parents, func(i, j int) bool {
+		return strings.Count(parents[i], "/") > strings.Count(parents[j], "/")
+	})
+	for _, p := range parents {
+		_ = os.Remove(filepath.Join(dir, filepath.FromSlash(p)))
+	}
+}
@@ -0,0 +1,83 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanup(t *testing.T) {
+	requireBinary(t)
+	dir := filepath.Join(t.TempDir(), "cache")
+	m := NewManager(WithExtractDir(dir))
+	if _, err := m.Extract(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Update(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewManager(WithExtractDir(dir), WithContentAddressed(true)).Extract(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+		entries, _ := os.ReadDir(dir)
+		t.Fatalf("extraction dir still exists (%v), holding %v", err, entries)
+	}
+	// nothing left to remove
+	if err := m.Cleanup(); err != nil {
+		t.Fatalf("second Cleanup: %v", err)
+	}
+}
+
+func TestCleanupKeepsForeignFiles(t *testing.T) {
+	requireBinary(t)
+	m := newTestManager(t)
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := filepath.Join(filepath.Dir(path), "settings.json")
+	if err := os.WriteFile(user, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("binary survived Cleanup: %v", err)
+	}
+	if _, err := os.Stat(user); err != nil {
+		t.Fatalf("Cleanup removed a file it didn't create: %v", err)
+	}
+}
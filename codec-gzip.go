@@ -0,0 +1,59 @@
+//go:build !warpnet_zstd
+// +build !warpnet_zstd
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// compressedExt is the extension of gzip-compressed embed assets.
+const compressedExt = ".gz"
+
+func newDecompressor(r io.Reader) (io.ReadCloser, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("embedded: open gzip stream: %w", err)
+	}
+	return zr, nil
+}
+
+// uncompressedSize reads the ISIZE field of the gzip trailer, which holds the
+// uncompressed length modulo 2^32.
+func uncompressedSize(r io.ReaderAt, n int64) int64 {
+	if n < 4 {
+		return 0
+	}
+	var trailer [4]byte
+	if _, err := r.ReadAt(trailer[:], n-4); err != nil {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint32(trailer[:]))
+}
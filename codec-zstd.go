@@ -0,0 +1,62 @@
+//go:build warpnet_zstd
+// +build warpnet_zstd
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressedExt is the extension of zstd-compressed embed assets.
+const compressedExt = ".zst"
+
+func newDecompressor(r io.Reader) (io.ReadCloser, error) {
+	// a single-threaded decoder works synchronously, without background goroutines
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(1<<32))
+	if err != nil {
+		return nil, fmt.Errorf("embedded: open zstd stream: %w", err)
+	}
+	return dec.IOReadCloser(), nil
+}
+
+// uncompressedSize reads the content size from the zstd frame header. It is 0 if
+// the encoder didn't record it, which the zstd CLI does by default.
+func uncompressedSize(r io.ReaderAt, n int64) int64 {
+	header := make([]byte, min(n, zstd.HeaderMaxSize))
+	if _, err := r.ReadAt(header, 0); err != nil && !errors.Is(err, io.EOF) {
+		return 0
+	}
+	var h zstd.Header
+	if err := h.Decode(header); err != nil || !h.HasFCS {
+		return 0
+	}
+	return int64(h.FrameContentSize)
+}
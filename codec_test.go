@@ -0,0 +1,170 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// codecs decompress the assets of both build variants, whichever one this
+// build embeds.
+var codecs = []struct {
+	ext        string
+	decompress func(io.Reader) (io.ReadCloser, error)
+}{
+	{".gz", func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }},
+	{".zst", func(r io.Reader) (io.ReadCloser, error) {
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	}},
+}
+
+// assetFile reads the compressed asset of this platform from bin/, which holds
+// both codec variants.
+func assetFile(t testing.TB, ext string) []byte {
+	t.Helper()
+	base := strings.TrimSuffix(assets[runtime.GOARCH].name, compressedExt)
+	data, err := os.ReadFile(filepath.FromSlash(base + ext))
+	if err != nil {
+		t.Skipf("no %s asset: %v", ext, err)
+	}
+	return data
+}
+
+func decompress(t testing.TB, data []byte, open func(io.Reader) (io.ReadCloser, error)) []byte {
+	t.Helper()
+	r, err := open(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestCodecsDecompressIdentically(t *testing.T) {
+	requireBinary(t)
+	want, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := expectedChecksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range codecs {
+		t.Run(c.ext, func(t *testing.T) {
+			data := decompress(t, assetFile(t, c.ext), c.decompress)
+			if !bytes.Equal(data, want) {
+				t.Fatalf("%s asset decompresses to %d bytes differing from the %d embedded ones", c.ext, len(data), len(want))
+			}
+			if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != expected {
+				t.Fatalf("%s asset doesn't match the sidecar checksum", c.ext)
+			}
+		})
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	requireBinary(t)
+	data, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zst := enc.EncodeAll(data, nil)
+
+	for i, compressed := range [][]byte{gz.Bytes(), zst} {
+		if out := decompress(t, compressed, codecs[i].decompress); !bytes.Equal(out, data) {
+			t.Fatalf("%s round trip changed the binary", codecs[i].ext)
+		}
+	}
+}
+
+// BenchmarkCodecs compares decompressing the binary from the gzip and zstd
+// assets with copying it raw, and reports the size of each embed.
+func BenchmarkCodecs(b *testing.B) {
+	requireBinary(b)
+	raw, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("raw", func(b *testing.B) {
+		b.SetBytes(int64(len(raw)))
+		for b.Loop() {
+			if _, err := io.Copy(io.Discard, bytes.NewReader(raw)); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(len(raw)), "embed-B")
+	})
+	for _, c := range codecs {
+		b.Run(strings.TrimPrefix(c.ext, "."), func(b *testing.B) {
+			data := assetFile(b, c.ext)
+			b.SetBytes(int64(len(raw)))
+			b.ReportAllocs()
+			for b.Loop() {
+				r, err := c.decompress(bytes.NewReader(data))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := io.Copy(io.Discard, r); err != nil {
+					b.Fatal(err)
+				}
+				_ = r.Close()
+			}
+			b.ReportMetric(float64(len(data)), "embed-B")
+		})
+	}
+}
@@ -0,0 +1,73 @@
+//go:build darwin
+// +build darwin
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// VerifyCodesign checks the code signature of the binary at path with
+// codesign --verify --deep --strict. If teamID isn't empty, the binary must
+// also be signed by that Apple team. An unsigned or modified binary fails.
+func VerifyCodesign(path, teamID string) error {
+	out, err := exec.Command("codesign", "--verify", "--deep", "--strict", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("embedded: codesign verify %s: %w: %s", path, err, bytes.TrimSpace(out))
+	}
+	if teamID == "" {
+		return nil
+	}
+
+	// codesign prints the signature details to stderr
+	out, err = exec.Command("codesign", "--display", "--verbose=2", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("embedded: codesign display %s: %w: %s", path, err, bytes.TrimSpace(out))
+	}
+	if actual := signingTeamID(out); actual != teamID {
+		return fmt.Errorf("embedded: %s is signed by team %q, expected %q", path, actual, teamID)
+	}
+	return nil
+}
+
+func signingTeamID(details []byte) string {
+	sc := bufio.NewScanner(bytes.NewReader(details))
+	for sc.Scan() {
+		if id, ok := strings.CutPrefix(sc.Text(), "TeamIdentifier="); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+func verifySignature(path, teamID string) error {
+	return VerifyCodesign(path, teamID)
+}
@@ -0,0 +1,78 @@
+//go:build darwin
+// +build darwin
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func requireCodesign(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("codesign"); err != nil {
+		t.Skip("codesign not available")
+	}
+}
+
+func TestVerifyCodesignUnsigned(t *testing.T) {
+	requireCodesign(t)
+	path := filepath.Join(t.TempDir(), "unsigned")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCodesign(path, ""); err == nil {
+		t.Fatal("unsigned fixture passed codesign verification")
+	}
+}
+
+func TestVerifyCodesignTeam(t *testing.T) {
+	requireCodesign(t)
+	// system binaries are signed by Apple without a team identifier
+	const signed = "/usr/bin/true"
+	if err := VerifyCodesign(signed, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCodesign(signed, "ABCDE12345"); err == nil {
+		t.Fatal("binary passed verification for a team it isn't signed by")
+	}
+}
+
+func TestSigningTeamID(t *testing.T) {
+	details := []byte("Executable=/tmp/warpnet-desktop\nAuthority=Developer ID Application\nTeamIdentifier=ABCDE12345\nSealed Resources=none\n")
+	if id := signingTeamID(details); id != "ABCDE12345" {
+		t.Fatalf("team = %q, want ABCDE12345", id)
+	}
+	if id := signingTeamID([]byte("TeamIdentifier=not set\n")); id != "not set" {
+		t.Fatalf("team = %q, want the raw value", id)
+	}
+	if id := signingTeamID(nil); id != "" {
+		t.Fatalf("team = %q, want empty", id)
+	}
+}
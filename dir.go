@@ -0,0 +1,150 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Warp-net/warpnet-desktop/bin"
+)
+
+const (
+	cacheDirName = "warpnet-desktop"
+
+	// CacheDirEnv overrides the default extraction dir.
+	CacheDirEnv = "WARPNET_DESKTOP_CACHE"
+)
+
+var (
+	extractDirMx sync.RWMutex
+	extractDir   string
+)
+
+// SetExtractDir sets the dir the desktop binary is extracted to.
+// An empty dir restores the default, see ExtractDir.
+func SetExtractDir(dir string) {
+	extractDirMx.Lock()
+	defer extractDirMx.Unlock()
+	extractDir = dir
+}
+
+// ExtractDir returns the dir the desktop binary is extracted to, creating it
+// with mode 0700 if it's missing. It is the dir set by SetExtractDir, otherwise
+// the WARPNET_DESKTOP_CACHE environment variable, otherwise warpnet-desktop
+// in the OS user cache dir. If there is no user cache dir, it is warpnet-desktop
+// next to the executable, see bin.BinaryDir and bin.SetBinaryPath.
+func ExtractDir() (string, error) {
+	return defaultManager.ExtractDir()
+}
+
+// ErrUnsafePath is returned when an extraction path resolves, through symlinks,
+// to a location outside its extraction dir, such as a cache dir an attacker has
+// pointed at a system path.
+var ErrUnsafePath = errors.New("embedded: unsafe extraction path")
+
+// checkInside returns ErrUnsafePath unless path, with symlinks resolved, lies
+// within root. Symlinks in the ancestors of root, like /var -> /private/var on
+// macOS, are resolved on both sides; root itself must not be a symlink, and path
+// may only use symlinks that stay within root. Missing parts of path are
+// resolved from their nearest existing ancestor.
+func checkInside(root, path string) error {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("embedded: resolve %s: %w", root, err)
+	}
+	parent, err := realPath(filepath.Dir(absRoot))
+	if err != nil {
+		return err
+	}
+	want := filepath.Join(parent, filepath.Base(absRoot))
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("embedded: resolve %s: %w", path, err)
+	}
+	real, err := realPath(absPath)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(want, real)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%w: %s resolves to %s, outside %s", ErrUnsafePath, path, real, root)
+	}
+	return nil
+}
+
+// realPath is filepath.EvalSymlinks for paths that may not exist yet. A
+// dangling symlink resolves to where it points, not to itself.
+func realPath(path string) (string, error) {
+	var missing []string
+	for {
+		real, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(append([]string{real}, missing...)...), nil
+		}
+		parent := filepath.Dir(path)
+		if !errors.Is(err, fs.ErrNotExist) || parent == path {
+			return "", fmt.Errorf("embedded: resolve %s: %w", path, err)
+		}
+		if target, err := os.Readlink(path); err == nil {
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(parent, target)
+			}
+			path = target
+			continue
+		}
+		missing = append([]string{filepath.Base(path)}, missing...)
+		path = parent
+	}
+}
+
+func resolveExtractDir() (string, error) {
+	extractDirMx.RLock()
+	dir := extractDir
+	extractDirMx.RUnlock()
+
+	if dir == "" {
+		dir = os.Getenv(CacheDirEnv)
+	}
+	if dir != "" {
+		return dir, nil
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err == nil {
+		return filepath.Join(cacheDir, cacheDirName), nil
+	}
+	// e.g. a service account without a home dir
+	binDir, binErr := bin.BinaryDir()
+	if binErr != nil {
+		return "", fmt.Errorf("embedded: resolve cache dir: %w, binary dir: %w", err, binErr)
+	}
+	return filepath.Join(binDir, cacheDirName), nil
+}
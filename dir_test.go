@@ -0,0 +1,110 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Warp-net/warpnet-desktop/bin"
+)
+
+func symlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+}
+
+func TestExtractRefusesSymlinkedBinary(t *testing.T) {
+	outside := t.TempDir()
+	victim := filepath.Join(outside, "victim")
+	if err := os.WriteFile(victim, []byte("keep me"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	dangling := filepath.Join(outside, "planted")
+
+	for name, target := range map[string]string{"existing": victim, "dangling": dangling} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			symlink(t, target, filepath.Join(dir, binaryName))
+			if _, err := ExtractTo(dir); !errors.Is(err, ErrUnsafePath) {
+				t.Fatalf("err = %v, want ErrUnsafePath", err)
+			}
+		})
+	}
+	if data, _ := os.ReadFile(victim); string(data) != "keep me" {
+		t.Fatalf("victim was overwritten with %q", data)
+	}
+	if _, err := os.Lstat(dangling); err == nil {
+		t.Fatal("extraction created the dangling target")
+	}
+}
+
+func TestEnsureExtractedRefusesSymlinkedDir(t *testing.T) {
+	requireBinary(t)
+	outside := t.TempDir()
+	dir := filepath.Join(t.TempDir(), "cache")
+	symlink(t, outside, dir)
+
+	if _, err := newTestManager(t).ensureExtracted(dir, false); !errors.Is(err, ErrUnsafePath) {
+		t.Fatalf("err = %v, want ErrUnsafePath", err)
+	}
+	if entries, _ := os.ReadDir(outside); len(entries) != 0 {
+		t.Fatalf("extraction wrote %v through the symlink", entries)
+	}
+}
+
+func TestCheckInside(t *testing.T) {
+	root := t.TempDir()
+	if err := checkInside(root, filepath.Join(root, "sub", "missing", binaryName)); err != nil {
+		t.Fatalf("path under root refused: %v", err)
+	}
+	if err := checkInside(root, filepath.Join(root, "..", "sibling")); !errors.Is(err, ErrUnsafePath) {
+		t.Fatalf("err = %v, want ErrUnsafePath for a path climbing out", err)
+	}
+}
+
+func TestExtractDirFallsBackToBinaryDir(t *testing.T) {
+	for _, env := range []string{CacheDirEnv, "XDG_CACHE_HOME", "HOME", "LocalAppData", "home"} {
+		t.Setenv(env, "")
+	}
+	if _, err := os.UserCacheDir(); err == nil {
+		t.Skip("the user cache dir doesn't depend on the environment here")
+	}
+	binDir := t.TempDir()
+	bin.SetBinaryPath(binDir)
+	t.Cleanup(func() { bin.SetBinaryPath("") })
+
+	dir, err := NewManager().resolveDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(binDir, cacheDirName); dir != want {
+		t.Fatalf("extraction dir = %s, want %s next to the executable", dir, want)
+	}
+}
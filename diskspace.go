@@ -0,0 +1,58 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+)
+
+// diskSpaceMargin is the free space CheckDiskSpace requires on top of Size().
+const diskSpaceMargin = 16 << 20
+
+// ErrInsufficientSpace is returned when the target filesystem can't hold the
+// extracted desktop binary.
+var ErrInsufficientSpace = errors.New("embedded: insufficient disk space")
+
+var errFreeSpaceUnsupported = errors.New("free space query is not supported")
+
+// CheckDiskSpace returns ErrInsufficientSpace if the filesystem holding dir has
+// less free space than the extracted binary needs plus a small margin. Extraction
+// runs this check before writing. It passes on platforms where free space can't
+// be queried.
+func CheckDiskSpace(dir string) error {
+	free, err := freeSpace(dir)
+	if errors.Is(err, errFreeSpaceUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("embedded: query free space of %s: %w", dir, err)
+	}
+	need := uint64(Size()) + diskSpaceMargin
+	if free < need {
+		return fmt.Errorf("%w: %s has %d bytes free, %d needed", ErrInsufficientSpace, dir, free, need)
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
-//go:build darwin
+//go:build darwin && (amd64 || arm64) && !warpnet_zstd
 // +build darwin
+// +build amd64 arm64
+// +build !warpnet_zstd
 
 /* License generated by licensor(https://github.com/Marvin9/licensor).
 
@@ -28,15 +30,18 @@ resulting from the use or misuse of this software.
 package embedded
 
 import (
-	_ "embed"
+	"embed"
+
 	_ "github.com/Warp-net/warpnet-desktop/bin"
 )
 
 // This file embeds the whole desktop to a single Golang binary file. DO NOT REMOVE.
+// Each GOARCH has a gzip-compressed binary and the sha256sum output of its
+// uncompressed form. Files are read from the FS on demand.
 //
-//go:embed bin/warpnet-desktop-darwin
-var desktopBinary []byte
+//go:embed bin/warpnet-desktop-darwin-amd64.gz bin/warpnet-desktop-darwin-amd64.sha256
+//go:embed bin/warpnet-desktop-darwin-arm64.gz bin/warpnet-desktop-darwin-arm64.sha256
+var desktopFS embed.FS
 
-func GetDesktopEmbedded() []byte {
-	return desktopBinary
-}
+// desktopArchs lists the GOARCH values embedded in desktopFS.
+var desktopArchs = []string{"amd64", "arm64"}
@@ -0,0 +1,50 @@
+//go:build (darwin && !amd64 && !arm64) || (linux && !amd64) || (windows && !amd64)
+// +build darwin,!amd64,!arm64 linux,!amd64 windows,!amd64
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import "embed"
+
+// The desktop is embedded for these GOOS/GOARCH tuples:
+//
+//	darwin/amd64, darwin/arm64  both binaries, picked at runtime, see GetDesktopEmbeddedFor
+//	linux/amd64
+//	windows/amd64
+//
+// Other operating systems build with no binary, see embed-unsupported.go, so
+// importers can check IsSupported at runtime. This file matches a supported OS
+// with an architecture that has no binary, where the result would only fail at
+// launch with "exec format error". The constant below can't be represented in
+// its type on purpose, so such a build fails here instead. To support the tuple,
+// add its binary to bin and an embed file for it.
+const desktopBinaryIsNotEmbeddedForThisGOARCH uint = -1
+
+// stubs so the constant above is the only compile error
+var (
+	desktopFS    embed.FS
+	desktopArchs []string
+)
@@ -1,5 +1,5 @@
-//go:build linux
-// +build linux
+//go:build linux && amd64 && !warpnet_zstd
+// +build linux,amd64,!warpnet_zstd
 
 /* License generated by licensor(https://github.com/Marvin9/licensor).
 
@@ -28,15 +28,17 @@ resulting from the use or misuse of this software.
 package embedded
 
 import (
-	_ "embed"
+	"embed"
+
 	_ "github.com/Warp-net/warpnet-desktop/bin"
 )
 
 // This file embeds the whole desktop to a single Golang binary file. DO NOT REMOVE.
+// Each GOARCH has a gzip-compressed binary and the sha256sum output of its
+// uncompressed form. Files are read from the FS on demand.
 //
-//go:embed bin/warpnet-desktop-linux
-var desktopBinary []byte
+//go:embed bin/warpnet-desktop-linux-amd64.gz bin/warpnet-desktop-linux-amd64.sha256
+var desktopFS embed.FS
 
-func LoadDesktopEmbedded() []byte {
-	return desktopBinary
-}
+// desktopArchs lists the GOARCH values embedded in desktopFS.
+var desktopArchs = []string{"amd64"}
@@ -0,0 +1,422 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrEmptyBinary is returned when the embedded desktop binary is empty,
+// which means the build embedded a missing or zero-length asset.
+var ErrEmptyBinary = errors.New("embedded: desktop binary is empty")
+
+// asset is a compressed desktop binary for one GOARCH in an FS. It is
+// streamed from the FS on demand; the decompressed bytes are only materialized
+// and cached when requested as a slice.
+type asset struct {
+	// fsys holds the binary, desktopFS outside of tests.
+	fsys fs.FS
+	// name is the path of the compressed binary in fsys.
+	name string
+	// sidecarName is the path of the sha256sum output of the uncompressed binary.
+	sidecarName string
+
+	// sem guards data and err. It is a channel rather than a mutex so callers
+	// waiting for another decompression can give up.
+	sem    chan struct{}
+	cached bool
+	data   []byte
+	err    error
+}
+
+var assets = newAssets(desktopFS, desktopArchs)
+
+func newAssets(fsys fs.FS, archs []string) map[string]*asset {
+	m := make(map[string]*asset, len(archs))
+	for _, goarch := range archs {
+		base := "bin/" + cacheDirName + "-" + runtime.GOOS + "-" + goarch + filepath.Ext(binaryName)
+		m[goarch] = &asset{
+			fsys:        fsys,
+			name:        base + compressedExt,
+			sidecarName: base + checksumSuffix,
+			sem:         make(chan struct{}, 1),
+		}
+	}
+	return m
+}
+
+// open returns a stream of the decompressed binary.
+func (a *asset) open() (io.ReadCloser, error) {
+	f, err := a.fsys.Open(a.name)
+	if err != nil {
+		return nil, fmt.Errorf("embedded: open %s: %w", a.name, err)
+	}
+	if info, err := f.Stat(); err != nil || info.Size() == 0 {
+		_ = f.Close()
+		return nil, fmt.Errorf("%w: embed asset %s is missing, build the desktop first", ErrEmptyBinary, a.name)
+	}
+	zr, err := newDecompressor(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &stream{Reader: zr, closers: []io.Closer{zr, f}}, nil
+}
+
+// bytes decompresses the binary once and caches the result. A decompression
+// aborted because ctx is done isn't cached, so a later call starts over.
+func (a *asset) bytes(ctx context.Context) ([]byte, error) {
+	select {
+	case a.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-a.sem }()
+
+	if a.cached {
+		return a.data, a.err
+	}
+	data, err := a.readAll(ctx)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	a.data, a.err, a.cached = data, err, true
+	return data, err
+}
+
+// decompressions counts the decompressions of a binary into memory. Since
+// bytes serializes them per asset and caches the result, it grows by one per
+// asset unless a decompression is canceled.
+var decompressions atomic.Int64
+
+func (a *asset) readAll(ctx context.Context) ([]byte, error) {
+	decompressions.Add(1)
+	r, err := a.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	buf := bytes.NewBuffer(make([]byte, 0, a.size()))
+	if _, err := buf.ReadFrom(&ctxReader{ctx: ctx, r: r}); err != nil {
+		return nil, fmt.Errorf("embedded: decompress desktop binary: %w", err)
+	}
+	if buf.Len() == 0 {
+		return nil, fmt.Errorf("%w: embed asset %s holds no data, rebuild the desktop", ErrEmptyBinary, a.name)
+	}
+	return buf.Bytes(), nil
+}
+
+// peek returns the decompressed bytes if they are already cached, without
+// waiting for a decompression in progress.
+func (a *asset) peek() ([]byte, bool) {
+	select {
+	case a.sem <- struct{}{}:
+	default:
+		return nil, false
+	}
+	defer func() { <-a.sem }()
+	return a.data, a.cached && a.err == nil
+}
+
+// size reads the uncompressed length from the compressed stream metadata.
+func (a *asset) size() int64 {
+	f, err := a.fsys.Open(a.name)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0
+	}
+	ra, ok := f.(io.ReaderAt)
+	if !ok {
+		return 0
+	}
+	return uncompressedSize(ra, info.Size())
+}
+
+func (a *asset) sidecar() ([]byte, error) {
+	return fs.ReadFile(a.fsys, a.sidecarName)
+}
+
+// stream is a decompressing reader that closes the decompressor and the
+// underlying file together.
+type stream struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (s *stream) Close() error {
+	var errs []error
+	for _, c := range s.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// ctxChunkSize bounds how much ctxReader reads between context checks.
+const ctxChunkSize = 64 << 10
+
+// ctxReader fails reads with the context error once ctx is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *ctxReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(p) > ctxChunkSize {
+		p = p[:ctxChunkSize]
+	}
+	return cr.r.Read(p)
+}
+
+func assetFor(goarch string) (*asset, error) {
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	a, ok := assets[goarch]
+	if !ok {
+		return nil, fmt.Errorf(
+			"embedded: no desktop binary for %s/%s, embedded architectures: %s",
+			runtime.GOOS, goarch, strings.Join(desktopArchs, ", "),
+		)
+	}
+	return a, nil
+}
+
+// IsSupported reports whether a desktop binary is embedded for this platform.
+// Binaries are embedded for darwin/amd64, darwin/arm64, linux/amd64 and
+// windows/amd64. Other operating systems build without one; other architectures
+// of these three fail to build, see embed-guard.go.
+func IsSupported() bool {
+	_, ok := assets[runtime.GOARCH]
+	return ok
+}
+
+// GetDesktopEmbedded returns the decompressed desktop binary, or nil if it
+// is missing or can't be decompressed. The reason is reported to the Logger,
+// or written to the standard logger if none is set, so it never goes unnoticed;
+// use GetDesktopEmbeddedErr to handle it instead.
+func GetDesktopEmbedded() []byte {
+	data, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		if hasLogger() {
+			logEvent("binary unavailable", "err", err)
+		} else {
+			log.Printf("embedded: desktop binary unavailable: %v", err)
+		}
+	}
+	return data
+}
+
+// GetDesktopEmbeddedErr returns the decompressed desktop binary for runtime.GOARCH.
+// Decompression happens once, the result is cached for subsequent calls.
+// Concurrent callers during a cold start wait for that one decompression and
+// share its result rather than each decompressing the binary.
+// With AllowDesktopPathOverride, it returns the file DesktopPathEnv names instead.
+func GetDesktopEmbeddedErr() ([]byte, error) {
+	return GetDesktopEmbeddedCtx(context.Background())
+}
+
+// GetDesktopEmbeddedCtx is GetDesktopEmbeddedErr that gives up once ctx is done,
+// returning ctx.Err(), e.g. when the user cancels a slow cold start. A canceled
+// decompression isn't cached; the next call starts over. Once decompression has
+// succeeded, the cached binary is returned immediately.
+func GetDesktopEmbeddedCtx(ctx context.Context) ([]byte, error) {
+	if path, ok := desktopOverride(); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("embedded: read %s: %w", DesktopPathEnv, err)
+		}
+		return data, nil
+	}
+	a, err := assetFor(runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	return a.bytes(ctx)
+}
+
+// GetDesktopEmbeddedFor returns the decompressed desktop binary built for goarch,
+// so a universal build can pick the binary at runtime. An empty goarch means
+// runtime.GOARCH, for which it is GetDesktopEmbeddedErr, dev override included;
+// the override stands for the native binary only, so other archs ignore it.
+// It fails if no binary is embedded for goarch.
+func GetDesktopEmbeddedFor(goarch string) ([]byte, error) {
+	if goarch == "" || goarch == runtime.GOARCH {
+		return GetDesktopEmbeddedErr()
+	}
+	a, err := assetFor(goarch)
+	if err != nil {
+		return nil, err
+	}
+	return a.bytes(context.Background())
+}
+
+// Size returns the length of the decompressed desktop binary without decompressing
+// it. The value is read from the compressed stream metadata. Size returns 0 if no binary is
+// embedded for this platform. With the dev override it is the size of that file.
+func Size() int64 {
+	if path, ok := desktopOverride(); ok {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0
+		}
+		return info.Size()
+	}
+	a, err := assetFor(runtime.GOARCH)
+	if err != nil {
+		return 0
+	}
+	return a.size()
+}
+
+// Reader returns a stream of the decompressed desktop binary, read from the
+// embedded FS on demand, so streaming callers never hold the whole binary in
+// memory. If the binary is missing or can't be decompressed, reads from the
+// returned reader fail with that error. With the dev override it reads that file.
+func Reader() io.Reader {
+	r, err := openDesktop()
+	if err != nil {
+		return errReader{err: err}
+	}
+	return r
+}
+
+// WriteTo streams the decompressed desktop binary to w without materializing it.
+// With the dev override it streams that file.
+func WriteTo(w io.Writer) (int64, error) {
+	r, err := openDesktop()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
+
+// openDesktop returns a stream of the desktop binary: the file DesktopPathEnv
+// names while the dev override applies, the decompressed embedded one otherwise.
+func openDesktop() (io.ReadCloser, error) {
+	if path, ok := desktopOverride(); ok {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("embedded: open %s: %w", DesktopPathEnv, err)
+		}
+		return f, nil
+	}
+	a, err := assetFor(runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	return a.open()
+}
+
+// ReaderAt returns random access to the decompressed desktop binary without
+// materializing it. The compressed stream can't seek, so every ReadAt decompresses
+// from the start up to off+len(p) and discards what precedes off: reading headers
+// is cheap, reading near the end costs a decompression pass but still allocates
+// nothing the size of the binary. Once GetDesktopEmbedded has cached the binary,
+// reads are served from memory. Reads at or past the end return io.EOF. If the
+// binary is missing or can't be decompressed, ReadAt fails with that error.
+// With the dev override it reads that file, opening it for every ReadAt.
+func ReaderAt() io.ReaderAt {
+	if path, ok := desktopOverride(); ok {
+		return &readerAt{path: path}
+	}
+	a, err := assetFor(runtime.GOARCH)
+	return &readerAt{a: a, err: err}
+}
+
+type readerAt struct {
+	a   *asset
+	err error
+	// path is the dev override file read instead of a.
+	path string
+}
+
+func (r *readerAt) ReadAt(p []byte, off int64) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("embedded: read at negative offset %d", off)
+	}
+	if r.path != "" {
+		f, err := os.Open(r.path)
+		if err != nil {
+			return 0, fmt.Errorf("embedded: open %s: %w", DesktopPathEnv, err)
+		}
+		defer f.Close()
+		return f.ReadAt(p, off)
+	}
+	if data, ok := r.a.peek(); ok {
+		return bytes.NewReader(data).ReadAt(p, off)
+	}
+
+	s, err := r.a.open()
+	if err != nil {
+		return 0, err
+	}
+	defer s.Close()
+	if _, err := io.CopyN(io.Discard, s, off); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		return 0, fmt.Errorf("embedded: decompress desktop binary: %w", err)
+	}
+	n, err := io.ReadFull(s, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return n, io.EOF
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("embedded: decompress desktop binary: %w", err)
+	}
+	return n, err
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,211 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// requireBinary skips tests that need an embedded binary on platforms built
+// without one.
+func requireBinary(t testing.TB) {
+	t.Helper()
+	if !IsSupported() {
+		t.Skip("no desktop binary is embedded for this platform")
+	}
+}
+
+// requireRunnableBinary skips tests that start the embedded binary where it is a
+// placeholder: only the linux embed is a real executable until the desktop is built.
+func requireRunnableBinary(t testing.TB) {
+	t.Helper()
+	requireBinary(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("the embedded binary is a placeholder on " + runtime.GOOS)
+	}
+}
+
+// newTestManager returns a Manager extracting into a fresh temp dir, so tests
+// don't share a cache with each other or with the user.
+func newTestManager(t testing.TB, opts ...Option) *Manager {
+	t.Helper()
+	return NewManager(append([]Option{WithExtractDir(t.TempDir())}, opts...)...)
+}
+
+// eventLog is a Logger recording the events it receives.
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) Log(event string, _ ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+// has reports whether event was logged.
+func (l *eventLog) has(event string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Contains(l.events, event)
+}
+
+// BenchmarkDecompress measures decompressing the embedded binary into memory and
+// reports the compressed embed size against the raw binary it stands for.
+func BenchmarkDecompress(b *testing.B) {
+	requireBinary(b)
+	a := assets[runtime.GOARCH]
+	info, err := fs.Stat(desktopFS, a.name)
+	if err != nil {
+		b.Fatal(err)
+	}
+	raw := Size()
+	b.SetBytes(raw)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := a.readAll(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(info.Size()), "embed-B")
+	b.ReportMetric(float64(raw), "raw-B")
+	b.ReportMetric(float64(info.Size())/float64(raw), "ratio")
+}
+
+func TestStreamingDoesNotMaterialize(t *testing.T) {
+	requireBinary(t)
+	before := decompressions.Load()
+	n, err := WriteTo(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != Size() {
+		t.Fatalf("WriteTo wrote %d bytes, want %d", n, Size())
+	}
+	if _, err := io.Copy(io.Discard, Reader()); err != nil {
+		t.Fatal(err)
+	}
+	if after := decompressions.Load(); after != before {
+		t.Fatalf("streaming decompressed the binary into memory %d times", after-before)
+	}
+}
+
+// BenchmarkWriteTo streams the binary, as stream-only callers do. Compare its
+// B/op with BenchmarkDecompress, which materializes the binary: streaming stays
+// at the codec buffers, not the binary size.
+func BenchmarkWriteTo(b *testing.B) {
+	requireBinary(b)
+	b.SetBytes(Size())
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := WriteTo(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestReaderAt(t *testing.T) {
+	requireBinary(t)
+	data, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := int64(len(data))
+
+	readers := map[string]io.ReaderAt{
+		// a fresh asset has nothing cached, so reads decompress
+		"streaming": &readerAt{a: newAssets(desktopFS, desktopArchs)[runtime.GOARCH]},
+		"cached":    ReaderAt(),
+	}
+	tests := []struct {
+		name    string
+		off     int64
+		len     int
+		n       int
+		wantErr error
+	}{
+		{"header", 0, 4, 4, nil},
+		{"last byte", size - 1, 1, 1, nil},
+		{"across the end", size - 2, 4, 2, io.EOF},
+		{"at the end", size, 1, 0, io.EOF},
+		{"past the end", size + 10, 1, 0, io.EOF},
+	}
+	for name, r := range readers {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				p := make([]byte, tt.len)
+				n, err := r.ReadAt(p, tt.off)
+				if n != tt.n || !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReadAt = %d, %v; want %d, %v", n, err, tt.n, tt.wantErr)
+				}
+				if want := data[min(tt.off, size):min(tt.off+int64(tt.n), size)]; !slices.Equal(p[:n], want) {
+					t.Fatalf("read %x, want %x", p[:n], want)
+				}
+			})
+		}
+		if _, err := r.ReadAt(make([]byte, 1), -1); err == nil {
+			t.Fatalf("%s: negative offset read without error", name)
+		}
+	}
+}
+
+func TestBytesDecompressesOnce(t *testing.T) {
+	requireBinary(t)
+	a := newAssets(desktopFS, desktopArchs)[runtime.GOARCH]
+	before := decompressions.Load()
+
+	const callers = 16
+	results := make([][]byte, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := a.bytes(context.Background())
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = data
+		}()
+	}
+	wg.Wait()
+
+	if n := decompressions.Load() - before; n != 1 {
+		t.Fatalf("%d concurrent callers decompressed %d times, want once", callers, n)
+	}
+	for _, data := range results[1:] {
+		if len(data) == 0 || &data[0] != &results[0][0] {
+			t.Fatal("callers got different copies of the binary")
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ExportBase64 streams the decompressed desktop binary to w as standard base64,
+// for support bundles or channels that only carry text. The binary is encoded
+// in chunks as it is decompressed, so it's never held in memory as a whole.
+//
+// To restore the binary, decode the output and compare its hash with the
+// SHA256 reported by GetMetadata:
+//
+//	base64 -d < warpnet-desktop.b64 > warpnet-desktop && sha256sum warpnet-desktop
+//
+// On Windows, certutil -decode warpnet-desktop.b64 warpnet-desktop.exe does the same.
+// With the dev override the exported file is that build, which GetMetadata
+// doesn't describe.
+func ExportBase64(w io.Writer) error {
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	if _, err := WriteTo(enc); err != nil {
+		return fmt.Errorf("embedded: export base64: %w", err)
+	}
+	// flush the final partial block and padding
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("embedded: export base64: %w", err)
+	}
+	return nil
+}
+
+// StreamTo is WriteTo for pushing the binary over a network connection. It
+// copies the decompressed binary to w through one 64KiB buffer and returns the
+// bytes written. Once ctx is done it stops with ctx.Err(), checked between
+// chunks. If w has a SetWriteDeadline method, like net.Conn and tls.Conn,
+// canceling ctx also interrupts a write stalled on a slow peer; the write
+// deadline is cleared again before StreamTo returns. With the dev override it
+// streams that file.
+func StreamTo(ctx context.Context, w io.Writer) (n int64, err error) {
+	r, err := openDesktop()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	if d, ok := w.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		interrupted := make(chan struct{})
+		stop := context.AfterFunc(ctx, func() {
+			_ = d.SetWriteDeadline(time.Now())
+			close(interrupted)
+		})
+		defer func() {
+			if !stop() {
+				<-interrupted
+				_ = d.SetWriteDeadline(time.Time{})
+			}
+		}()
+	}
+
+	buf := make([]byte, ctxChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		nr, readErr := r.Read(buf)
+		if nr > 0 {
+			nw, writeErr := w.Write(buf[:nr])
+			n += int64(nw)
+			if writeErr == nil && nw < nr {
+				writeErr = io.ErrShortWrite
+			}
+			if writeErr != nil {
+				if ctx.Err() != nil {
+					return n, ctx.Err()
+				}
+				return n, fmt.Errorf("embedded: stream desktop binary: %w", writeErr)
+			}
+		}
+		if readErr == io.EOF {
+			return n, nil
+		}
+		if readErr != nil {
+			return n, fmt.Errorf("embedded: decompress desktop binary: %w", readErr)
+		}
+	}
+}
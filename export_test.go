@@ -0,0 +1,89 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestStreamTo(t *testing.T) {
+	requireBinary(t)
+	want, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, server := net.Pipe()
+	received := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(server)
+		received <- data
+	}()
+
+	n, err := StreamTo(context.Background(), client)
+	client.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data := <-received; n != int64(len(want)) || !bytes.Equal(data, want) {
+		t.Fatalf("streamed %d bytes, peer got %d, want the %d byte binary", n, len(data), len(want))
+	}
+}
+
+func TestStreamToCanceled(t *testing.T) {
+	requireBinary(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if n, err := StreamTo(ctx, io.Discard); n != 0 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("StreamTo = %d, %v; want 0, context.Canceled", n, err)
+	}
+}
+
+func TestStreamToStalledPeer(t *testing.T) {
+	requireBinary(t)
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// the peer takes the first bytes, then stalls and the caller gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	taken := min(Size()/2, 1<<10)
+	go func() {
+		_, _ = io.ReadFull(server, make([]byte, taken))
+		cancel()
+	}()
+
+	n, err := StreamTo(ctx, client)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if n != taken {
+		t.Fatalf("streamed %d bytes, want the %d the peer read", n, taken)
+	}
+}
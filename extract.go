@@ -0,0 +1,329 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+const (
+	checksumSuffix = ".sha256"
+	lockFileName   = ".warpnet-desktop.lock"
+)
+
+// ExtractTo writes the embedded desktop binary into dir and returns the full path
+// of the written executable. The binary is written to a temporary file first and
+// renamed into place, so a partially written file is never left under the final name.
+// It's decompressed while being written and never held in memory as a whole.
+// It returns ErrUnsafePath if dir or the binary path is a symlink leading out of dir.
+func ExtractTo(dir string) (path string, err error) {
+	return ExtractToWithProgress(dir, nil)
+}
+
+// ExtractToWithProgress is ExtractTo that reports progress while writing.
+// The callback is invoked from the calling goroutine after every 64KiB written
+// and exactly once with written == total after the binary is in place.
+// A nil callback disables reporting.
+func ExtractToWithProgress(dir string, progress func(written, total int64)) (path string, err error) {
+	return defaultManager.extractTo(dir, progress, "")
+}
+
+// ExtractVerified is ExtractTo that hashes the binary while writing it and
+// compares the hash with the embedded sha256 sidecar, so verifying takes no
+// second pass over the data. On a mismatch the written file is discarded before
+// it is renamed into place, so an unverified binary never appears under its name.
+func ExtractVerified(dir string) (path string, err error) {
+	expected, err := expectedChecksum()
+	if err != nil {
+		return "", err
+	}
+	return defaultManager.extractTo(dir, nil, expected)
+}
+
+// extractTo writes the binary into dir. A non-empty expected is the hex SHA-256
+// the written data must match.
+func (m *Manager) extractTo(dir string, progress func(written, total int64), expected string) (path string, err error) {
+	if err := checkInside(dir, dir); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("embedded: create extraction dir: %w", err)
+	}
+
+	path = filepath.Join(dir, binaryName)
+	if err := checkInside(dir, path); err != nil {
+		return "", err
+	}
+	if err := m.extractFile(path, progress, expected); err != nil {
+		return "", err
+	}
+	// the rename replaces a symlink at path rather than following it, check anyway
+	if err := checkInside(dir, path); err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// extractFile is extractFile counted in the Manager's stats. Every extraction
+// of the binary by the Manager goes through it.
+func (m *Manager) extractFile(path string, progress func(written, total int64), expected string) error {
+	started := time.Now()
+	n, err := extractFile(path, progress, expected)
+	m.stats.extractionTime.Add(int64(time.Since(started)))
+	if err != nil {
+		m.stats.extractionFailures.Add(1)
+		return err
+	}
+	m.stats.extractions.Add(1)
+	m.stats.bytesWritten.Add(n)
+	return nil
+}
+
+// extractFile atomically writes the desktop binary to path, verifying it against
+// expected unless that is empty, and returns the number of bytes written. The
+// binary is decompressed straight into the file, so memory use is bounded by the
+// codec window rather than the binary size.
+func extractFile(path string, progress func(written, total int64), expected string) (int64, error) {
+	a, err := assetFor(runtime.GOARCH)
+	if err != nil {
+		return 0, err
+	}
+	if err := CheckDiskSpace(filepath.Dir(path)); err != nil {
+		return 0, err
+	}
+	r, err := a.open()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	total := Size()
+	counted := &countingReader{r: r}
+	var src io.Reader = counted
+	if progress != nil {
+		src = &progressReader{r: src, total: total, progress: progress}
+	}
+	var h hash.Hash
+	if expected != "" {
+		h = sha256.New()
+		src = io.TeeReader(src, h)
+	}
+
+	verify := func() error {
+		if counted.n == 0 {
+			return fmt.Errorf("%w: embed asset %s holds no data, rebuild the desktop", ErrEmptyBinary, a.name)
+		}
+		if h == nil {
+			return nil
+		}
+		if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+			return fmt.Errorf("embedded: %s checksum mismatch: expected %s, actual %s", path, expected, actual)
+		}
+		return nil
+	}
+	if err := writeAtomicVerified(path, src, binaryMode, verify); err != nil {
+		return 0, err
+	}
+	if err := clearQuarantine(path); err != nil {
+		return 0, fmt.Errorf("embedded: clear quarantine: %w", err)
+	}
+	if progress != nil {
+		progress(total, total)
+	}
+	return counted.n, nil
+}
+
+// EnsureExtracted extracts the embedded desktop binary into dir unless a copy
+// with the same checksum is already there. The checksum of the extracted copy
+// is kept in a sidecar file next to it; a missing binary, a size mismatch or
+// a stale sidecar trigger re-extraction, and so does a copy whose contents don't
+// match, see VerifyCachedBeforeLaunch. An empty dir means ExtractDir.
+//
+// With ContentAddressed set, the binary goes to a subdir of dir named after its
+// checksum instead. With AllowDesktopPathOverride, the file DesktopPathEnv names
+// is returned without extracting anything. Like ExtractTo, it returns
+// ErrUnsafePath for symlinks leading out of dir.
+//
+// Concurrent callers, including other processes, are serialized with an advisory
+// lock on the dir, so only the first one writes and the rest observe its copy.
+func EnsureExtracted(dir string) (path string, err error) {
+	return defaultManager.ensureExtracted(dir, defaultManager.verifyCachedBeforeLaunch())
+}
+
+// ensureExtracted is EnsureExtracted; verify re-hashes a cached copy, see
+// VerifyCachedBeforeLaunch.
+func (m *Manager) ensureExtracted(dir string, verify bool) (path string, err error) {
+	if path, ok := m.desktopOverride(); ok {
+		return path, nil
+	}
+	sum, err := checksum()
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		if dir, err = m.ExtractDir(); err != nil {
+			return "", err
+		}
+	}
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = fmt.Errorf("embedded: unlock extraction dir: %w", unlockErr)
+		}
+	}()
+
+	path = filepath.Join(dir, binaryName)
+	if m.isContentAddressed() {
+		path = filepath.Join(dir, contentDirName(sum), binaryName)
+	}
+	if err := checkInside(dir, path); err != nil {
+		return "", err
+	}
+	if isExtracted(path, sum) {
+		if !verify {
+			m.log("cache hit", "path", path)
+			m.stats.cacheHits.Add(1)
+			return path, nil
+		}
+		if err := verifyFile(path, sum); err != nil {
+			m.log("cache corrupt", "path", path, "err", err)
+		} else {
+			m.log("cache hit", "path", path, "verified", true)
+			m.stats.cacheHits.Add(1)
+			return path, nil
+		}
+	}
+
+	m.log("extracting", "path", path)
+	if _, err := m.extractTo(filepath.Dir(path), nil, ""); err != nil {
+		m.log("extraction failed", "path", path, "err", err)
+		return "", err
+	}
+	if err := WriteFileAtomic(path+checksumSuffix, sidecar(sum, binaryName), fileMode); err != nil {
+		return "", err
+	}
+	m.log("extracted", "path", path)
+	return path, nil
+}
+
+// VerifyCachedBeforeLaunch makes EnsureExtracted, and so the Launch family,
+// re-hash an already extracted binary before returning it, so a copy that was
+// corrupted or tampered with since it was written is replaced rather than run.
+// A cache hit otherwise only checks the size and the checksum sidecar. It is on
+// by default; turning it off saves reading the whole binary on every launch.
+var VerifyCachedBeforeLaunch = true
+
+// ContentAddressed makes EnsureExtracted, and so the Launch family, extract the
+// binary to dir/<sha256 prefix>/warpnet-desktop. Identical binaries then share
+// one path, so going back to a version that was extracted before is a cache hit.
+// Prune and Cleanup handle these dirs as well. Set it before launching.
+var ContentAddressed bool
+
+// contentDirName is the subdir ContentAddressed extracts the binary with sum to.
+func contentDirName(sum string) string {
+	return sum[:versionPrefixLen]
+}
+
+// isContentDirName reports whether name looks like a contentDirName.
+func isContentDirName(name string) bool {
+	return isVersionPrefix(name)
+}
+
+// lockDir creates dir if needed and takes the advisory extraction lock on it.
+// It refuses a dir that is a symlink, so nothing is created where it points.
+func lockDir(dir string) (unlock func() error, err error) {
+	if err := checkInside(dir, dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("embedded: create extraction dir: %w", err)
+	}
+	return lockFile(filepath.Join(dir, lockFileName))
+}
+
+func isExtracted(path, sum string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != Size() {
+		return false
+	}
+	stored, err := os.ReadFile(path + checksumSuffix)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(stored, sidecar(sum, filepath.Base(path)))
+}
+
+// sidecar formats the checksum file of the binary name the same way sha256sum does.
+func sidecar(sum, name string) []byte {
+	return []byte(sum + "  " + name + "\n")
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+const progressStep = 64 << 10
+
+// progressReader reports the running total whenever another progressStep bytes
+// went through it. The final total is left to the caller, so it's reported
+// once the file is actually in place.
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	next     int64
+	total    int64
+	progress func(written, total int64)
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.read += int64(n)
+	if pr.read >= pr.next+progressStep && pr.read < pr.total {
+		pr.progress(pr.read, pr.total)
+		pr.next = pr.read
+	}
+	return n, err
+}
@@ -0,0 +1,351 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEnsureExtractedFirstRun(t *testing.T) {
+	requireBinary(t)
+	m := newTestManager(t)
+
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := m.ExtractDir()
+	if want := filepath.Join(dir, binaryName); path != want {
+		t.Fatalf("path = %s, want %s", path, want)
+	}
+	if err := verifyFile(path, Checksum()); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := os.ReadFile(path + checksumSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := sidecar(Checksum(), binaryName); !bytes.Equal(stored, want) {
+		t.Fatalf("sidecar = %q, want %q", stored, want)
+	}
+	if s := m.Stats(); s.Extractions != 1 || s.CacheHits != 0 {
+		t.Fatalf("extractions = %d, cache hits = %d, want 1 and 0", s.Extractions, s.CacheHits)
+	}
+}
+
+func TestEnsureExtractedCacheHit(t *testing.T) {
+	requireBinary(t)
+	m := newTestManager(t)
+
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != path {
+		t.Fatalf("second path = %s, want %s", again, path)
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(before, after) {
+		t.Fatal("cache hit rewrote the binary")
+	}
+	if s := m.Stats(); s.Extractions != 1 || s.CacheHits != 1 {
+		t.Fatalf("extractions = %d, cache hits = %d, want 1 and 1", s.Extractions, s.CacheHits)
+	}
+}
+
+func TestEnsureExtractedCorrupt(t *testing.T) {
+	requireBinary(t)
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, path string)
+	}{
+		{"truncated binary", func(t *testing.T, path string) {
+			if err := os.Truncate(path, Size()/2); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"missing binary", func(t *testing.T, path string) {
+			if err := os.Remove(path); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"stale sidecar", func(t *testing.T, path string) {
+			stale := sidecar(string(bytes.Repeat([]byte("0"), 64)), binaryName)
+			if err := os.WriteFile(path+checksumSuffix, stale, fileMode); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t)
+			path, err := m.Extract()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.corrupt(t, path)
+
+			if _, err := m.Extract(); err != nil {
+				t.Fatal(err)
+			}
+			if err := verifyFile(path, Checksum()); err != nil {
+				t.Fatal(err)
+			}
+			if s := m.Stats(); s.Extractions != 2 || s.CacheHits != 0 {
+				t.Fatalf("extractions = %d, cache hits = %d, want 2 and 0", s.Extractions, s.CacheHits)
+			}
+		})
+	}
+}
+
+func TestEnsureExtractedConcurrent(t *testing.T) {
+	requireBinary(t)
+	dir := t.TempDir()
+
+	// one Manager per goroutine, like separate launcher processes sharing a cache
+	const n = 8
+	managers := make([]*Manager, n)
+	paths := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		managers[i] = NewManager(WithExtractDir(dir))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			paths[i], errs[i] = managers[i].Extract()
+		}()
+	}
+	wg.Wait()
+
+	var extractions int64
+	for i := range n {
+		if errs[i] != nil {
+			t.Fatalf("goroutine %d: %v", i, errs[i])
+		}
+		if paths[i] != paths[0] {
+			t.Fatalf("goroutine %d got %s, goroutine 0 got %s", i, paths[i], paths[0])
+		}
+		extractions += managers[i].Stats().Extractions
+	}
+	if extractions != 1 {
+		t.Fatalf("%d extractions, want 1", extractions)
+	}
+	if err := verifyFile(paths[0], Checksum()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// corruptInPlace flips a byte of the file at path, keeping its size, so only
+// hashing it reveals the damage.
+func corruptInPlace(t *testing.T, path string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)/2] ^= 0xff
+	if err := os.WriteFile(path, data, binaryMode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnsureExtractedDetectsSameSizeCorruption(t *testing.T) {
+	requireBinary(t)
+	log := &eventLog{}
+	m := newTestManager(t, WithLogger(log), WithVerifyCachedBeforeLaunch(true))
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	corruptInPlace(t, path)
+
+	again, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !log.has("cache corrupt") {
+		t.Fatal("same-size corruption went unnoticed")
+	}
+	if err := verifyFile(again, Checksum()); err != nil {
+		t.Fatalf("corrupt copy wasn't replaced: %v", err)
+	}
+	if s := m.Stats(); s.Extractions != 2 || s.CacheHits != 0 {
+		t.Fatalf("extractions = %d, cache hits = %d, want 2 and 0", s.Extractions, s.CacheHits)
+	}
+}
+
+func TestEnsureExtractedUnverifiedTrustsCache(t *testing.T) {
+	requireBinary(t)
+	m := newTestManager(t, WithVerifyCachedBeforeLaunch(false))
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	corruptInPlace(t, path)
+
+	// without the re-hash only the size and sidecar are checked
+	if _, err := m.Extract(); err != nil {
+		t.Fatal(err)
+	}
+	if s := m.Stats(); s.Extractions != 1 || s.CacheHits != 1 {
+		t.Fatalf("extractions = %d, cache hits = %d, want 1 and 1", s.Extractions, s.CacheHits)
+	}
+}
+
+func TestEnsureExtractedContentAddressed(t *testing.T) {
+	requireBinary(t)
+	dir := t.TempDir()
+	first := NewManager(WithExtractDir(dir), WithContentAddressed(true))
+	second := NewManager(WithExtractDir(dir), WithContentAddressed(true))
+
+	path, err := first.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, contentDirName(Checksum()), binaryName); path != want {
+		t.Fatalf("path = %s, want %s", path, want)
+	}
+	again, err := second.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != path {
+		t.Fatalf("second path = %s, want %s", again, path)
+	}
+	if s := second.Stats(); s.Extractions != 0 || s.CacheHits != 1 {
+		t.Fatalf("second extraction: extractions = %d, cache hits = %d, want 0 and 1", s.Extractions, s.CacheHits)
+	}
+	if _, err := os.Lstat(filepath.Join(dir, binaryName)); err == nil {
+		t.Fatal("content-addressed extraction wrote the stable name too")
+	}
+}
+
+// swapAsset replaces the native asset for the test with one read from a copy
+// of the embedded FS that edit has modified.
+func swapAsset(t *testing.T, edit func(a *asset, files fstest.MapFS)) {
+	t.Helper()
+	orig := assets[runtime.GOARCH]
+	files := fstest.MapFS{}
+	for _, name := range []string{orig.name, orig.sidecarName} {
+		data, err := fs.ReadFile(desktopFS, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = &fstest.MapFile{Data: data}
+	}
+	a := newAssets(files, []string{runtime.GOARCH})[runtime.GOARCH]
+	edit(a, files)
+	assets[runtime.GOARCH] = a
+	t.Cleanup(func() { assets[runtime.GOARCH] = orig })
+}
+
+func TestExtractVerifiedCorrupt(t *testing.T) {
+	requireBinary(t)
+	tests := []struct {
+		name, reason string
+		corrupt      func(a *asset, files fstest.MapFS)
+	}{
+		// the binary decompresses fine but isn't the one the sidecar names
+		{"checksum mismatch", "checksum mismatch", func(a *asset, files fstest.MapFS) {
+			files[a.sidecarName].Data = []byte(strings.Repeat("0", 64) + "  " + binaryName + "\n")
+		}},
+		{"truncated stream", "write " + binaryName, func(a *asset, files fstest.MapFS) {
+			data := files[a.name].Data
+			files[a.name].Data = data[:len(data)/2]
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swapAsset(t, tt.corrupt)
+			dir := t.TempDir()
+			_, err := ExtractVerified(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.reason) {
+				t.Fatalf("err = %v, want a %s error", err, tt.reason)
+			}
+			if names := dirNames(t, dir); len(names) != 0 {
+				t.Fatalf("failed extraction left %v behind", names)
+			}
+		})
+	}
+}
+
+// BenchmarkExtractTo compares the streaming extraction with decompressing the
+// whole binary first; its B/op stays bounded by the codec and copy buffers
+// rather than growing with the binary.
+func BenchmarkExtractTo(b *testing.B) {
+	requireBinary(b)
+	b.Run("streaming", func(b *testing.B) {
+		dir := b.TempDir()
+		b.SetBytes(Size())
+		b.ReportAllocs()
+		var path string
+		for b.Loop() {
+			var err error
+			if path, err = ExtractTo(dir); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		if err := verifyFile(path, Checksum()); err != nil {
+			b.Fatal(err)
+		}
+	})
+	b.Run("in-memory", func(b *testing.B) {
+		path := filepath.Join(b.TempDir(), binaryName)
+		a := assets[runtime.GOARCH]
+		b.SetBytes(Size())
+		b.ReportAllocs()
+		for b.Loop() {
+			data, err := a.readAll(context.Background())
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := WriteFileAtomic(path, data, binaryMode); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
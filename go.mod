@@ -1,3 +1,8 @@
 module github.com/Warp-net/warpnet-desktop
 
 go 1.24.0
+
+require (
+	github.com/klauspost/compress v1.18.2
+	golang.org/x/sys v0.38.0
+)
@@ -0,0 +1,85 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+// helperEnv makes the test binary run as a helper child instead of the tests,
+// see TestMain. Its value names the helper.
+const helperEnv = "WARPNET_DESKTOP_TEST_HELPER"
+
+// helpers are the behaviors the test binary can take on as a launched child.
+// Platform-specific tests register theirs in init.
+var helpers = map[string]func() int{}
+
+func TestMain(m *testing.M) {
+	if name := os.Getenv(helperEnv); name != "" {
+		helper, ok := helpers[name]
+		if !ok {
+			os.Stderr.WriteString("unknown helper " + name + "\n")
+			os.Exit(3)
+		}
+		os.Exit(helper())
+	}
+	os.Exit(m.Run())
+}
+
+// helperCommand returns a command running the test binary as the named helper.
+func helperCommand(t testing.TB, name string, args ...string) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(testExecutable(t), args...)
+	cmd.Env = append(os.Environ(), helperEnv+"="+name)
+	return cmd
+}
+
+// helperManager returns a test Manager launching the test binary in place of
+// the desktop through the dev override, with LaunchOptions.Env picking the helper.
+func helperManager(t *testing.T, opts ...Option) *Manager {
+	t.Helper()
+	t.Setenv(DesktopPathEnv, testExecutable(t))
+	return newTestManager(t, append([]Option{WithDesktopPathOverride(true)}, opts...)...)
+}
+
+// helperOptions returns LaunchOptions running the named helper.
+func helperOptions(name string, args ...string) LaunchOptions {
+	return LaunchOptions{Args: args, Env: []string{helperEnv + "=" + name}}
+}
+
+func testExecutable(t testing.TB) string {
+	t.Helper()
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
+		t.Skip("can't start processes on " + runtime.GOOS)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skipf("can't locate the test binary: %v", err)
+	}
+	return exe
+}
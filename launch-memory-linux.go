@@ -0,0 +1,103 @@
+//go:build linux
+// +build linux
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"golang.org/x/sys/unix"
+)
+
+// LaunchInMemory starts the embedded desktop binary without writing it to disk.
+// The binary is streamed into an anonymous memfd_create file, so it is never held
+// in the launcher's memory as a whole, and executed through its /proc path. On kernels without memfd_create (before 3.17) it falls back to
+// Launch, which extracts the binary to the cache dir.
+//
+// Cancellation and the returned Process follow Launch.
+func LaunchInMemory(ctx context.Context, args ...string) (*Process, error) {
+	return defaultManager.LaunchInMemory(ctx, args...)
+}
+
+// LaunchInMemory is the package-level LaunchInMemory using the Manager's settings.
+func (m *Manager) LaunchInMemory(ctx context.Context, args ...string) (*Process, error) {
+	opts := LaunchOptions{Args: args}
+	if err := m.checkLaunch(opts); err != nil {
+		return nil, err
+	}
+	if err := m.preLaunchChecks(); err != nil {
+		return nil, err
+	}
+	fd, err := unix.MemfdCreate(binaryName, unix.MFD_CLOEXEC)
+	if errors.Is(err, unix.ENOSYS) {
+		return m.launchWithOptions(ctx, opts, m.verifyCachedBeforeLaunch())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("embedded: memfd_create: %w", err)
+	}
+	// the kernel keeps the file alive for the child once exec succeeded
+	f := os.NewFile(uintptr(fd), binaryName)
+	defer f.Close()
+
+	if err := m.writeBinary(f); err != nil {
+		return nil, err
+	}
+
+	// /proc/self would resolve to the child, which doesn't inherit the CLOEXEC fd
+	path := fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), fd)
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = os.Environ()
+	setCancel(cmd, m.shutdownGrace())
+
+	// the process image of a memfd reads as /memfd:<name>, see processImage
+	return m.startCommand(cmd, 0, "/memfd:"+binaryName)
+}
+
+// writeBinary streams the desktop binary into f without holding it in memory,
+// or the file DesktopPathEnv names if the dev override is active.
+func (m *Manager) writeBinary(f *os.File) error {
+	if path, ok := m.desktopOverride(); ok {
+		src, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("embedded: read %s: %w", DesktopPathEnv, err)
+		}
+		defer src.Close()
+		if _, err := io.Copy(f, src); err != nil {
+			return fmt.Errorf("embedded: write memfd: %w", err)
+		}
+		return nil
+	}
+	if _, err := WriteTo(f); err != nil {
+		return fmt.Errorf("embedded: write memfd: %w", err)
+	}
+	return nil
+}
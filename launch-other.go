@@ -0,0 +1,53 @@
+//go:build !unix && !windows
+// +build !unix,!windows
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"os"
+	"os/exec"
+)
+
+// There are no process groups, sessions or signals to rely on here, so the
+// helpers below use the plain os/exec behavior.
+
+func setProcessGroup(_ *exec.Cmd) {}
+
+func setDetached(_ *exec.Cmd) {}
+
+// terminate kills the process: graceful termination isn't available.
+func terminate(p *os.Process) error {
+	return p.Kill()
+}
+
+func cleanupAfterStart(_ *Process, cleanup func()) {
+	cleanup()
+}
+
+func startElevated(_ string, _ []string, _ func(string, ...any)) (*Process, error) {
+	return nil, ErrElevationUnsupported
+}
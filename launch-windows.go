@@ -0,0 +1,174 @@
+//go:build windows
+// +build windows
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// setProcessGroup starts the process in its own process group, which is required
+// to deliver a console control event to it without hitting the parent as well.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= windows.CREATE_NEW_PROCESS_GROUP
+}
+
+// setDetached starts the process without a console and outside the parent's
+// process group, so closing the parent console doesn't stop it.
+func setDetached(cmd *exec.Cmd) {
+	setProcessGroup(cmd)
+	cmd.SysProcAttr.CreationFlags |= windows.DETACHED_PROCESS
+}
+
+// terminate asks the process to exit with a CTRL_BREAK_EVENT, the closest
+// Windows has to SIGTERM. It fails for processes that have no console.
+func terminate(p *os.Process) error {
+	return windows.GenerateConsoleCtrlEvent(windows.CTRL_BREAK_EVENT, uint32(p.Pid))
+}
+
+// cleanupAfterStart runs cleanup once the process exits, since Windows keeps
+// a running executable locked.
+func cleanupAfterStart(p *Process, cleanup func()) {
+	cleanupAfterExit(p, cleanup)
+}
+
+const (
+	seeMaskNoCloseProcess = 0x00000040
+	seeMaskNoAsync        = 0x00000100
+)
+
+var procShellExecuteExW = windows.NewLazySystemDLL("shell32.dll").NewProc("ShellExecuteExW")
+
+// shellExecuteInfo mirrors SHELLEXECUTEINFOW, which x/sys/windows doesn't define.
+type shellExecuteInfo struct {
+	size          uint32
+	mask          uint32
+	hwnd          windows.Handle
+	verb          *uint16
+	file          *uint16
+	parameters    *uint16
+	directory     *uint16
+	show          int32
+	instApp       windows.Handle
+	idList        uintptr
+	class         *uint16
+	keyClass      windows.Handle
+	hotKey        uint32
+	iconOrMonitor windows.Handle
+	process       windows.Handle
+}
+
+// startElevated starts the binary at path with args via ShellExecuteEx with the
+// runas verb. The returned Process tracks the elevated process through the
+// handle ShellExecuteEx returns, which is closed once the process exits, and
+// reports its exit to log.
+func startElevated(path string, args []string, log func(event string, kv ...any)) (*Process, error) {
+	verb, err := windows.UTF16PtrFromString("runas")
+	if err != nil {
+		return nil, err
+	}
+	file, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	params, err := windows.UTF16PtrFromString(windows.ComposeCommandLine(args))
+	if err != nil {
+		return nil, err
+	}
+	dir, err := windows.UTF16PtrFromString(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	info := shellExecuteInfo{
+		mask:       seeMaskNoCloseProcess | seeMaskNoAsync,
+		verb:       verb,
+		file:       file,
+		parameters: params,
+		directory:  dir,
+		show:       windows.SW_SHOWNORMAL,
+	}
+	info.size = uint32(unsafe.Sizeof(info))
+	if ok, _, err := procShellExecuteExW.Call(uintptr(unsafe.Pointer(&info))); ok == 0 {
+		return nil, fmt.Errorf("embedded: start elevated desktop: %w", err)
+	}
+	h := info.process
+	if h == 0 {
+		return nil, errors.New("embedded: start elevated desktop: no process handle returned")
+	}
+	pid, err := windows.GetProcessId(h)
+	if err != nil {
+		_ = windows.CloseHandle(h)
+		return nil, fmt.Errorf("embedded: elevated desktop pid: %w", err)
+	}
+
+	// the handle is closed under mx once the process exits, so kill never
+	// reaches a handle value that was reused
+	var (
+		mx     sync.Mutex
+		closed bool
+	)
+	wait := func() (int, error) {
+		_, err := windows.WaitForSingleObject(h, windows.INFINITE)
+		var code uint32
+		if err == nil {
+			err = windows.GetExitCodeProcess(h, &code)
+		}
+		mx.Lock()
+		defer mx.Unlock()
+		_ = windows.CloseHandle(h)
+		closed = true
+		if err != nil {
+			return -1, fmt.Errorf("embedded: wait for elevated desktop: %w", err)
+		}
+		return int(code), nil
+	}
+	kill := func() error {
+		mx.Lock()
+		defer mx.Unlock()
+		if closed {
+			return os.ErrProcessDone
+		}
+		return windows.TerminateProcess(h, 1)
+	}
+	terminate := func() error {
+		return windows.GenerateConsoleCtrlEvent(windows.CTRL_BREAK_EVENT, pid)
+	}
+	return newForeignProcess(int(pid), wait, terminate, kill, log), nil
+}
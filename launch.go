@@ -0,0 +1,422 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// LaunchOptions configures LaunchWithOptions. The zero value launches the desktop
+// like Launch does; each field documents what it changes from that default.
+type LaunchOptions struct {
+	// Args are passed to the desktop verbatim. Default: none.
+	Args []string
+	// Env is appended to the inherited environment, so its entries win over
+	// inherited variables with the same name. Default: the inherited environment.
+	Env []string
+	// Stdin feeds the desktop's standard input, e.g. a session token that must
+	// stay off the process table. It is copied in a goroutine owned by the process
+	// and the child's stdin is closed once Stdin returns io.EOF. An *os.File is
+	// handed to the child directly. Done isn't closed before the copy returns, so
+	// a reader that never ends should stop once the process exits. Default: nil,
+	// stdin reads from the null device.
+	Stdin io.Reader
+	// Stdout and Stderr receive the desktop's output. The copying goroutines are
+	// owned by the process: they are drained and the pipes closed before Done is
+	// closed. Default: nil, the output is discarded.
+	Stdout, Stderr io.Writer
+	// AllowedFlags, if not nil, makes the launch fail when Args hold a flag missing
+	// from it, see ValidateArgs. Default: nil, args aren't checked.
+	AllowedFlags []string
+	// StartTimeout bounds how long starting the process may take. Default: zero,
+	// the StartTimeout of the Manager applies.
+	StartTimeout time.Duration
+	// RequireSignature verifies the code signature of the binary before starting
+	// it, with SignatureTeamID as the expected team if set, see VerifyCodesign.
+	// Default: false, the RequireSignature and SignatureTeamID of the Manager apply.
+	RequireSignature bool
+	SignatureTeamID  string
+	// Ephemeral extracts the binary into a fresh temp dir that is removed once
+	// it's no longer needed, see LaunchEphemeral. Default: false, the binary is
+	// extracted into ExtractDir and kept.
+	Ephemeral bool
+	// Detached starts the desktop in its own session or process group, so it
+	// survives the launcher exiting, see LaunchDetached. Default: false, the
+	// desktop stays attached to the launcher.
+	Detached bool
+	// Elevated starts the desktop with administrator rights through the UAC prompt,
+	// see LaunchElevated. Only supported on Windows. Env, Stdin, Stdout, Stderr,
+	// Detached and StartTimeout don't apply, nor does ctx. Default: false.
+	Elevated bool
+}
+
+// ErrElevationUnsupported is returned for elevated launches outside Windows.
+var ErrElevationUnsupported = errors.New("embedded: elevated launch is only supported on Windows")
+
+// LaunchWithOptions extracts the embedded desktop binary and starts it as opts
+// describe. Canceling ctx stops the process. The returned Process waits for the
+// desktop in the background; watch it with Done or Wait.
+//
+// The binary is executed directly, never through a shell.
+func LaunchWithOptions(ctx context.Context, opts LaunchOptions) (*Process, error) {
+	return defaultManager.LaunchWithOptions(ctx, opts)
+}
+
+// LaunchWithOptions is the package-level LaunchWithOptions using the Manager's settings.
+func (m *Manager) LaunchWithOptions(ctx context.Context, opts LaunchOptions) (*Process, error) {
+	return m.launchWithOptions(ctx, opts, m.verifyCachedBeforeLaunch())
+}
+
+// launchWithOptions is LaunchWithOptions; verify re-hashes a cached binary
+// before it's started, see VerifyCachedBeforeLaunch.
+func (m *Manager) launchWithOptions(ctx context.Context, opts LaunchOptions, verify bool) (*Process, error) {
+	if err := m.checkLaunch(opts); err != nil {
+		return nil, err
+	}
+	path, cleanup, err := m.prepare(opts, verify)
+	if err != nil {
+		return nil, err
+	}
+	return m.launch(ctx, path, cleanup, opts)
+}
+
+// checkLaunch rejects opts that can't be launched before anything is extracted.
+func (m *Manager) checkLaunch(opts LaunchOptions) error {
+	if opts.AllowedFlags != nil {
+		if err := ValidateArgs(opts.AllowedFlags, opts.Args); err != nil {
+			return err
+		}
+	}
+	if opts.Elevated && runtime.GOOS != "windows" {
+		return ErrElevationUnsupported
+	}
+	if m.isSingleInstance() {
+		running, pid, err := m.IsRunning()
+		if err != nil {
+			return err
+		}
+		if running {
+			return fmt.Errorf("%w (pid %d)", ErrAlreadyRunning, pid)
+		}
+	}
+	return nil
+}
+
+// launch starts the binary at path as opts describe. cleanup is called if the
+// start fails, or once the binary isn't needed anymore for ephemeral launches.
+func (m *Manager) launch(ctx context.Context, path string, cleanup func(), opts LaunchOptions) (*Process, error) {
+	// an ephemeral binary is gone before a later launch could check it
+	record := path
+	if opts.Ephemeral {
+		record = ""
+	}
+	var (
+		p   *Process
+		err error
+	)
+	if opts.Elevated {
+		m.log("launching", "path", path, "args", opts.Args, "elevated", true)
+		if p, err = startElevated(path, opts.Args, m.log); err != nil {
+			m.log("launch failed", "path", path, "err", err)
+			m.stats.launchFailures.Add(1)
+		} else {
+			m.log("launched", "pid", p.PID())
+			m.track(p, record)
+		}
+	} else {
+		p, err = m.startCommand(m.command(ctx, path, opts), opts.StartTimeout, record)
+	}
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	if opts.Ephemeral {
+		if m.extractsResources() {
+			// the process runs in the temp dir and reads its resources from there
+			cleanupAfterExit(p, cleanup)
+		} else {
+			cleanupAfterStart(p, cleanup)
+		}
+	}
+	return p, nil
+}
+
+// cleanupAfterExit calls cleanup once p has exited.
+func cleanupAfterExit(p *Process, cleanup func()) {
+	go func() {
+		<-p.Done()
+		cleanup()
+	}()
+}
+
+// command returns a command for the binary at path configured as opts describe.
+func (m *Manager) command(ctx context.Context, path string, opts LaunchOptions) *exec.Cmd {
+	cmd := command(ctx, path, opts.Args, m.shutdownGrace())
+	if opts.Ephemeral && !m.extractsResources() {
+		// the temp dir may be removed while the process runs, see LaunchEphemeral
+		cmd.Dir = ""
+	}
+	cmd.Env = append(cmd.Env, opts.Env...)
+	cmd.Stdin = opts.Stdin
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	if opts.Detached {
+		setDetached(cmd)
+	}
+	return cmd
+}
+
+// startCommand starts cmd, waits for it in the background and tracks it, see
+// track. Every launch that is a child of the launcher starts here. A timeout of
+// zero means the StartTimeout of the Manager.
+func (m *Manager) startCommand(cmd *exec.Cmd, timeout time.Duration, record string) (*Process, error) {
+	if timeout == 0 {
+		timeout = m.launchTimeout()
+	}
+	if err := m.start(cmd, timeout); err != nil {
+		m.stats.launchFailures.Add(1)
+		return nil, err
+	}
+	p := newProcess(cmd, m.log)
+	m.track(p, record)
+	return p, nil
+}
+
+// track counts the launch of p in the stats and records it in the pidfile as
+// started from record, see IsRunning. An empty record skips the pidfile.
+func (m *Manager) track(p *Process, record string) {
+	m.watch(p, time.Now())
+	if record == "" {
+		return
+	}
+	if err := m.writePIDFile(p, record); err != nil {
+		m.log("pidfile failed", "pid", p.PID(), "err", err)
+	}
+}
+
+// prepare runs the pre-launch checks and puts the binary and the resources in
+// place. cleanup removes what an ephemeral launch extracted and is a no-op otherwise.
+// verify is passed on to ensureExtracted.
+func (m *Manager) prepare(opts LaunchOptions, verify bool) (path string, cleanup func(), err error) {
+	cleanup = func() {}
+	if err := m.preLaunchChecks(); err != nil {
+		return "", nil, err
+	}
+
+	if opts.Ephemeral && !m.overrideActive() {
+		var dir string
+		if dir, err = os.MkdirTemp("", cacheDirName+"-*"); err != nil {
+			return "", nil, fmt.Errorf("embedded: create temp dir: %w", err)
+		}
+		trackExitCleanup(dir)
+		cleanup = func() {
+			untrackExitCleanup(dir)
+			_ = os.RemoveAll(dir)
+		}
+		path, err = m.extractTo(dir, nil, "")
+	} else {
+		// a dev override runs in place, ephemeral or not
+		path, err = m.ensureExtracted("", verify)
+	}
+
+	if required, teamID := m.signatureRequired(); err == nil && (opts.RequireSignature || required) {
+		if opts.SignatureTeamID != "" {
+			teamID = opts.SignatureTeamID
+		}
+		err = verifySignature(path, teamID)
+	}
+	if err == nil && m.extractsResources() {
+		err = ExtractResources(filepath.Dir(path))
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// Launch extracts the embedded desktop binary into ExtractDir and starts it
+// with the given args. The process inherits the parent environment and runs with
+// the extraction dir as its working directory. Canceling ctx stops the process.
+//
+// The returned Process waits for the desktop in the background; watch it with
+// Done or Wait.
+func Launch(ctx context.Context, args ...string) (*Process, error) {
+	return LaunchWithOptions(ctx, LaunchOptions{Args: args})
+}
+
+// LaunchWithOutput is Launch with the child's stdout and stderr copied to the
+// given writers. A nil writer discards that stream. The copying goroutines are
+// owned by the process: they are drained and the pipes closed before Done is closed.
+func LaunchWithOutput(ctx context.Context, stdout, stderr io.Writer, args ...string) (*Process, error) {
+	return LaunchWithOptions(ctx, LaunchOptions{Args: args, Stdout: stdout, Stderr: stderr})
+}
+
+// LaunchWithEnv is Launch with env appended to the inherited environment.
+// Entries of env win over inherited variables with the same name. To replace
+// the environment entirely, use Command and set cmd.Env before starting it.
+func LaunchWithEnv(ctx context.Context, env []string, args ...string) (*Process, error) {
+	return LaunchWithOptions(ctx, LaunchOptions{Args: args, Env: env})
+}
+
+// Command extracts the embedded desktop binary into ExtractDir and returns a
+// command for it that is ready to start but not started. It is configured the
+// same way Launch configures it, so callers can adjust env, stdio or SysProcAttr
+// before calling Start. The binary is executed directly, never through a shell.
+func Command(ctx context.Context, args ...string) (*exec.Cmd, error) {
+	return defaultManager.Command(ctx, args...)
+}
+
+// Command is the package-level Command using the Manager's settings.
+func (m *Manager) Command(ctx context.Context, args ...string) (*exec.Cmd, error) {
+	path, _, err := m.prepare(LaunchOptions{}, m.verifyCachedBeforeLaunch())
+	if err != nil {
+		return nil, err
+	}
+	return command(ctx, path, args, m.shutdownGrace()), nil
+}
+
+// CommandWithEnv is Command with env appended to the inherited environment,
+// see LaunchWithEnv.
+func CommandWithEnv(ctx context.Context, env []string, args ...string) (*exec.Cmd, error) {
+	cmd, err := Command(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Env = append(cmd.Env, env...)
+	return cmd, nil
+}
+
+// LaunchEphemeral is Launch for single-shot runs that leave nothing on disk.
+// The binary is extracted into a fresh temp dir that is removed once it's no
+// longer needed: right after start on Unix, where the running process keeps the
+// unlinked file alive, and after the process exits on Windows, where the file
+// stays locked while running. With ExtractResourcesOnLaunch the resources are
+// extracted into the temp dir too, so it is kept until the process exits on
+// every platform and the process runs in it. Otherwise the process runs in the
+// working directory of the launcher rather than in a dir that is removed under
+// it. The temp dir is also removed if start fails.
+func LaunchEphemeral(ctx context.Context, args ...string) (*Process, error) {
+	return LaunchWithOptions(ctx, LaunchOptions{Args: args, Ephemeral: true})
+}
+
+// LaunchDetached starts the desktop in its own session (Unix) or detached process
+// group (Windows), so it survives the launcher exiting. Its stdio is redirected
+// to the null device. The returned PID can be recorded to stop it later.
+func LaunchDetached(args ...string) (pid int, err error) {
+	p, err := LaunchWithOptions(context.Background(), LaunchOptions{Args: args, Detached: true})
+	if err != nil {
+		return 0, err
+	}
+	return p.PID(), nil
+}
+
+// LaunchElevated starts the desktop with administrator rights on Windows: it is
+// started through ShellExecuteEx with the runas verb, which shows the UAC prompt.
+// The process isn't a child of the launcher, so only its PID is returned; use
+// LaunchWithOptions with Elevated to watch it. Unlike Launch it runs with the
+// environment of the elevated user, and its stdio isn't connected. It returns
+// windows.ERROR_CANCELLED if the user declines the prompt, and
+// ErrElevationUnsupported on other platforms.
+func LaunchElevated(args ...string) (pid int, err error) {
+	p, err := LaunchWithOptions(context.Background(), LaunchOptions{Args: args, Elevated: true})
+	if err != nil {
+		return 0, err
+	}
+	return p.PID(), nil
+}
+
+func command(ctx context.Context, path string, args []string, grace time.Duration) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Env = os.Environ()
+	setCancel(cmd, grace)
+	return cmd
+}
+
+// StartTimeout bounds how long starting the desktop process may take, since
+// Start can block, e.g. while antivirus scans the freshly written binary.
+// Zero disables the timeout. Set it before launching.
+var StartTimeout = 30 * time.Second
+
+// start starts cmd within timeout and reports the launch to the Logger.
+// A timeout of zero or less disables it.
+func start(cmd *exec.Cmd, timeout time.Duration) error {
+	return defaultManager.start(cmd, timeout)
+}
+
+func (m *Manager) start(cmd *exec.Cmd, timeout time.Duration) error {
+	m.log("launching", "path", cmd.Path, "args", cmd.Args[1:])
+	err := startWithTimeout(cmd, timeout)
+	if err != nil {
+		m.log("launch failed", "path", cmd.Path, "err", err)
+		return err
+	}
+	m.log("launched", "pid", cmd.Process.Pid)
+	return nil
+}
+
+// startWithTimeout starts cmd within timeout. exec.Cmd.Start can't be canceled,
+// so on timeout the attempt is abandoned and a process that starts afterwards is killed.
+func startWithTimeout(cmd *exec.Cmd, timeout time.Duration) error {
+	if timeout <= 0 {
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("embedded: start desktop: %w", err)
+		}
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Start()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("embedded: start desktop: %w", err)
+		}
+		return nil
+	case <-timer.C:
+	}
+	go func() {
+		if err := <-done; err == nil {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+		}
+	}()
+	return fmt.Errorf("embedded: start desktop: timed out after %s", timeout)
+}
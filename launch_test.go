@@ -0,0 +1,125 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	helpers["echo-env"] = echoEnv
+	helpers["cat"] = cat
+	helpers["pwd"] = pwd
+}
+
+// pwd prints its working directory.
+func pwd() int {
+	dir, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(dir)
+	return 0
+}
+
+// cat copies its stdin to stdout and exits once stdin is closed.
+func cat() int {
+	if _, err := io.Copy(os.Stdout, os.Stdin); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// echoEnv prints the value of each variable named by its args, one per line.
+func echoEnv() int {
+	for _, name := range os.Args[1:] {
+		fmt.Println(os.Getenv(name))
+	}
+	return 0
+}
+
+// runHelper launches the named helper through m and returns its output once it exited.
+func runHelper(t *testing.T, m *Manager, opts LaunchOptions) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	opts.Stdout, opts.Stderr = &stdout, &stderr
+	p, err := m.LaunchWithOptions(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatalf("helper failed: %v, stderr: %s", err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestLaunchWithEnv(t *testing.T) {
+	m := helperManager(t)
+	t.Setenv("WARPNET_TEST_INHERITED", "inherited")
+	t.Setenv("WARPNET_TEST_OVERRIDDEN", "inherited")
+
+	opts := helperOptions("echo-env", "WARPNET_TEST_CUSTOM", "WARPNET_TEST_INHERITED", "WARPNET_TEST_OVERRIDDEN")
+	opts.Env = append(opts.Env, "WARPNET_TEST_CUSTOM=custom", "WARPNET_TEST_OVERRIDDEN=custom")
+	out := runHelper(t, m, opts)
+
+	// Env is appended to the inherited environment and wins over it
+	if want := "custom\ninherited\ncustom\n"; strings.ReplaceAll(out, "\r\n", "\n") != want {
+		t.Fatalf("helper printed %q, want %q", out, want)
+	}
+}
+
+func TestLaunchWithStdin(t *testing.T) {
+	m := helperManager(t)
+	const token = "session-token-3c1f9a\n"
+
+	opts := helperOptions("cat")
+	opts.Stdin = strings.NewReader(token)
+	if out := runHelper(t, m, opts); out != token {
+		t.Fatalf("helper echoed %q, want the token %q", out, token)
+	}
+
+	// a file is handed to the child directly rather than copied
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte(token), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	opts.Stdin = f
+	if out := runHelper(t, m, opts); out != token {
+		t.Fatalf("helper echoed %q from a file, want the token %q", out, token)
+	}
+}
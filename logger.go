@@ -0,0 +1,72 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import "sync"
+
+// Logger receives the lifecycle events of this package: extraction, launches,
+// process exits and supervisor restarts. kv holds alternating keys and values,
+// in the style of slog, so an adapter for any logging library is a few lines.
+//
+// Log may be called from several goroutines at once.
+type Logger interface {
+	Log(event string, kv ...any)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(string, ...any) {}
+
+var (
+	loggerMx sync.RWMutex
+	logger   Logger = nopLogger{}
+)
+
+// SetLogger sets the Logger that receives the package events. A nil logger
+// restores the default, which drops them, except for the failure of
+// GetDesktopEmbedded, which goes to the standard logger.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	loggerMx.Lock()
+	defer loggerMx.Unlock()
+	logger = l
+}
+
+// hasLogger reports whether SetLogger installed a Logger.
+func hasLogger() bool {
+	loggerMx.RLock()
+	defer loggerMx.RUnlock()
+	_, nop := logger.(nopLogger)
+	return !nop
+}
+
+func logEvent(event string, kv ...any) {
+	loggerMx.RLock()
+	l := logger
+	loggerMx.RUnlock()
+	l.Log(event, kv...)
+}
@@ -0,0 +1,83 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// LaunchManaged is Launch that ties the desktop to the launcher's lifetime:
+// on SIGINT or SIGTERM (Ctrl-C, Ctrl-Break and console close on Windows) the
+// desktop is stopped through Process.Shutdown with DefaultShutdownGrace, or the
+// grace given WithShutdownGrace, the exit cleanup of RegisterCleanupOnExit
+// runs, and the launcher then exits with status 128 plus the signal number.
+//
+// The handler is removed once the desktop exits or stop is called, whichever
+// comes first, leaving signal handling to the app again. stop doesn't stop the
+// desktop. Signal handling is process-wide, so only one LaunchManaged should be
+// active at a time.
+func LaunchManaged(ctx context.Context, args ...string) (p *Process, stop func(), err error) {
+	return defaultManager.LaunchManaged(ctx, args...)
+}
+
+// LaunchManaged is the package-level LaunchManaged using the Manager's settings.
+func (m *Manager) LaunchManaged(ctx context.Context, args ...string) (p *Process, stop func(), err error) {
+	p, err = m.Launch(ctx, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	managedLaunches.Add(1)
+	stopped := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			managedLaunches.Add(-1)
+			close(stopped)
+		})
+	}
+
+	go func() {
+		select {
+		case sig := <-signals:
+			m.log("signal received", "signal", sig, "pid", p.PID())
+			_ = p.Shutdown(m.shutdownGrace())
+			runExitCleanup()
+			os.Exit(exitStatus(sig))
+		case <-p.Done():
+			stop()
+		case <-stopped:
+		}
+	}()
+	return p, stop, nil
+}
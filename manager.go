@@ -0,0 +1,316 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// Manager extracts, launches and maintains the desktop binary in one extraction
+// dir with its own settings, so a library, or each of several parallel tests,
+// can use its own cache root and Logger without touching package state.
+//
+// The package-level functions, like EnsureExtracted, Launch and Cleanup, are
+// wrappers around a default Manager that reads the package settings:
+// SetExtractDir, SetLogger, ContentAddressed, AllowDesktopPathOverride,
+// SingleInstance, VerifyCachedBeforeLaunch, RequireSignature and SignatureTeamID,
+// StartTimeout, VerifyPlatformOnLaunch, ExtractResourcesOnLaunch,
+// ReadinessPollInterval, ReadinessTimeout and SelfTestArgs, and it stops
+// processes with DefaultShutdownGrace. A Manager falls back to those for every
+// Option it isn't given. The codec is
+// chosen at build time, see the warpnet_zstd tag, and the decompressed binary
+// and its checksum are cached once per process, as they are the same for every
+// Manager. A Manager is safe for concurrent use.
+type Manager struct {
+	dir              string
+	logger           Logger
+	contentAddressed *bool
+	allowOverride    *bool
+	singleInstance   *bool
+	verifyCached     *bool
+	requireSignature *bool
+	signatureTeamID  string
+	startTimeout     *time.Duration
+	verifyPlatform   *bool
+	extractResources *bool
+	pollInterval     *time.Duration
+	readyTimeout     *time.Duration
+	selfTestArgs     *[]string
+	grace            *time.Duration
+
+	stats stats
+}
+
+// Option configures a Manager, see NewManager.
+type Option func(*Manager)
+
+// WithExtractDir makes the Manager extract into dir instead of ExtractDir.
+func WithExtractDir(dir string) Option {
+	return func(m *Manager) { m.dir = dir }
+}
+
+// WithLogger makes the Manager report its events to l instead of the Logger
+// set by SetLogger. A nil l drops them.
+func WithLogger(l Logger) Option {
+	return func(m *Manager) {
+		if l == nil {
+			l = nopLogger{}
+		}
+		m.logger = l
+	}
+}
+
+// WithContentAddressed overrides ContentAddressed for the Manager.
+func WithContentAddressed(on bool) Option {
+	return func(m *Manager) { m.contentAddressed = &on }
+}
+
+// WithDesktopPathOverride overrides AllowDesktopPathOverride for the Manager.
+func WithDesktopPathOverride(allow bool) Option {
+	return func(m *Manager) { m.allowOverride = &allow }
+}
+
+// WithSingleInstance overrides SingleInstance for the Manager.
+func WithSingleInstance(on bool) Option {
+	return func(m *Manager) { m.singleInstance = &on }
+}
+
+// WithVerifyCachedBeforeLaunch overrides VerifyCachedBeforeLaunch for the Manager.
+func WithVerifyCachedBeforeLaunch(on bool) Option {
+	return func(m *Manager) { m.verifyCached = &on }
+}
+
+// WithRequireSignature overrides RequireSignature and SignatureTeamID for the
+// Manager. An empty teamID accepts any valid signature.
+func WithRequireSignature(on bool, teamID string) Option {
+	return func(m *Manager) {
+		m.requireSignature = &on
+		m.signatureTeamID = teamID
+	}
+}
+
+// WithStartTimeout overrides StartTimeout for the Manager. Zero disables the timeout.
+func WithStartTimeout(d time.Duration) Option {
+	return func(m *Manager) { m.startTimeout = &d }
+}
+
+// WithVerifyPlatformOnLaunch overrides VerifyPlatformOnLaunch for the Manager.
+func WithVerifyPlatformOnLaunch(on bool) Option {
+	return func(m *Manager) { m.verifyPlatform = &on }
+}
+
+// WithExtractResourcesOnLaunch overrides ExtractResourcesOnLaunch for the Manager.
+func WithExtractResourcesOnLaunch(on bool) Option {
+	return func(m *Manager) { m.extractResources = &on }
+}
+
+// WithReadinessPollInterval overrides ReadinessPollInterval for the Manager.
+func WithReadinessPollInterval(d time.Duration) Option {
+	return func(m *Manager) { m.pollInterval = &d }
+}
+
+// WithReadinessTimeout overrides ReadinessTimeout for the Manager. Zero disables
+// the timeout.
+func WithReadinessTimeout(d time.Duration) Option {
+	return func(m *Manager) { m.readyTimeout = &d }
+}
+
+// WithSelfTestArgs overrides SelfTestArgs for the Manager.
+func WithSelfTestArgs(args ...string) Option {
+	args = slices.Clone(args)
+	return func(m *Manager) { m.selfTestArgs = &args }
+}
+
+// WithShutdownGrace makes the Manager give processes stopped by context
+// cancellation, LaunchManaged or SelfTest grace to exit instead of
+// DefaultShutdownGrace.
+func WithShutdownGrace(grace time.Duration) Option {
+	return func(m *Manager) { m.grace = &grace }
+}
+
+// NewManager returns a Manager configured by opts.
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// defaultManager backs the package-level functions.
+var defaultManager = &Manager{}
+
+// ExtractDir returns the dir the Manager extracts to, creating it with mode 0700
+// if it's missing.
+func (m *Manager) ExtractDir() (string, error) {
+	dir, err := m.resolveDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("embedded: create extraction dir: %w", err)
+	}
+	return dir, nil
+}
+
+// Extract is EnsureExtracted into the Manager's extraction dir.
+func (m *Manager) Extract() (string, error) {
+	return m.ensureExtracted("", m.verifyCachedBeforeLaunch())
+}
+
+// Launch is the package-level Launch using the Manager's settings.
+func (m *Manager) Launch(ctx context.Context, args ...string) (*Process, error) {
+	return m.LaunchWithOptions(ctx, LaunchOptions{Args: args})
+}
+
+// Verify is the package-level Verify. The embedded binary is the same for every
+// Manager, so the result is shared.
+func (m *Manager) Verify() error {
+	return Verify()
+}
+
+// Cleanup is the package-level Cleanup for the Manager's extraction dir.
+func (m *Manager) Cleanup() error {
+	removeStaleEphemeral()
+	dir, err := m.resolveDir()
+	if err != nil {
+		return err
+	}
+	return cleanupDir(dir)
+}
+
+// Prune is the package-level Prune for the Manager's extraction dir.
+func (m *Manager) Prune(maxAge time.Duration, keepLast int) (int, error) {
+	dir, err := m.resolveDir()
+	if err != nil {
+		return 0, err
+	}
+	return prune(dir, maxAge, keepLast)
+}
+
+func (m *Manager) resolveDir() (string, error) {
+	if m.dir != "" {
+		return m.dir, nil
+	}
+	return resolveExtractDir()
+}
+
+func (m *Manager) log(event string, kv ...any) {
+	if m.logger != nil {
+		m.logger.Log(event, kv...)
+		return
+	}
+	logEvent(event, kv...)
+}
+
+func (m *Manager) isContentAddressed() bool {
+	if m.contentAddressed != nil {
+		return *m.contentAddressed
+	}
+	return ContentAddressed
+}
+
+func (m *Manager) overrideAllowed() bool {
+	if m.allowOverride != nil {
+		return *m.allowOverride
+	}
+	return AllowDesktopPathOverride
+}
+
+func (m *Manager) isSingleInstance() bool {
+	if m.singleInstance != nil {
+		return *m.singleInstance
+	}
+	return SingleInstance
+}
+
+func (m *Manager) verifyCachedBeforeLaunch() bool {
+	if m.verifyCached != nil {
+		return *m.verifyCached
+	}
+	return VerifyCachedBeforeLaunch
+}
+
+// signatureRequired reports whether launches verify the code signature, and
+// the team it must be made by.
+func (m *Manager) signatureRequired() (bool, string) {
+	if m.requireSignature != nil {
+		return *m.requireSignature, m.signatureTeamID
+	}
+	return RequireSignature, SignatureTeamID
+}
+
+func (m *Manager) launchTimeout() time.Duration {
+	if m.startTimeout != nil {
+		return *m.startTimeout
+	}
+	return StartTimeout
+}
+
+func (m *Manager) verifyPlatformOnLaunch() bool {
+	if m.verifyPlatform != nil {
+		return *m.verifyPlatform
+	}
+	return VerifyPlatformOnLaunch
+}
+
+func (m *Manager) extractResourcesOnLaunch() bool {
+	if m.extractResources != nil {
+		return *m.extractResources
+	}
+	return ExtractResourcesOnLaunch
+}
+
+func (m *Manager) readinessPollInterval() time.Duration {
+	if m.pollInterval != nil {
+		return *m.pollInterval
+	}
+	return ReadinessPollInterval
+}
+
+func (m *Manager) readinessTimeout() time.Duration {
+	if m.readyTimeout != nil {
+		return *m.readyTimeout
+	}
+	return ReadinessTimeout
+}
+
+func (m *Manager) selfTestArguments() []string {
+	if m.selfTestArgs != nil {
+		return slices.Clone(*m.selfTestArgs)
+	}
+	return slices.Clone(SelfTestArgs)
+}
+
+func (m *Manager) shutdownGrace() time.Duration {
+	if m.grace != nil {
+		return *m.grace
+	}
+	return DefaultShutdownGrace
+}
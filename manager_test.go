@@ -0,0 +1,99 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManagerDefaults(t *testing.T) {
+	m := NewManager()
+	if d := m.readinessPollInterval(); d != ReadinessPollInterval {
+		t.Errorf("poll interval = %v, want ReadinessPollInterval", d)
+	}
+	if d := m.readinessTimeout(); d != ReadinessTimeout {
+		t.Errorf("readiness timeout = %v, want ReadinessTimeout", d)
+	}
+	if args := m.selfTestArguments(); !slices.Equal(args, SelfTestArgs) {
+		t.Errorf("selftest args = %q, want SelfTestArgs", args)
+	}
+	if d := m.shutdownGrace(); d != DefaultShutdownGrace {
+		t.Errorf("shutdown grace = %v, want DefaultShutdownGrace", d)
+	}
+}
+
+func TestManagerSelfTestArgs(t *testing.T) {
+	args := []string{"--selftest", "--headless"}
+	m := NewManager(WithSelfTestArgs(args...))
+	args[1] = "changed"
+	if got := m.selfTestArguments(); !slices.Equal(got, []string{"--selftest", "--headless"}) {
+		t.Fatalf("selftest args = %q, want a copy of the option", got)
+	}
+	if !slices.Equal(SelfTestArgs, []string{"--selftest"}) {
+		t.Fatalf("option leaked into SelfTestArgs: %q", SelfTestArgs)
+	}
+	if got := NewManager(WithSelfTestArgs()).selfTestArguments(); len(got) != 0 {
+		t.Fatalf("selftest args = %q, want none", got)
+	}
+}
+
+func TestManagerShutdownGrace(t *testing.T) {
+	m := NewManager(WithShutdownGrace(time.Second))
+	cmd := m.command(context.Background(), filepath.Join(t.TempDir(), binaryName), LaunchOptions{})
+	if cmd.WaitDelay != time.Second {
+		t.Fatalf("WaitDelay = %v, want the Manager's grace", cmd.WaitDelay)
+	}
+}
+
+func TestManagerReadinessOptions(t *testing.T) {
+	m := NewManager(WithReadinessPollInterval(time.Millisecond), WithReadinessTimeout(time.Hour))
+	calls := 0
+	started := time.Now()
+	err := m.waitReady(context.Background(), func(context.Context) error {
+		if calls++; calls < 5 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// at ReadinessPollInterval the 5 probes would take a second
+	if elapsed := time.Since(started); elapsed >= 4*ReadinessPollInterval {
+		t.Fatalf("5 probes took %v, the poll interval option wasn't used", elapsed)
+	}
+
+	m = NewManager(WithReadinessPollInterval(time.Millisecond), WithReadinessTimeout(20*time.Millisecond))
+	err = m.waitReady(context.Background(), func(context.Context) error { return errors.New("down") })
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "down") {
+		t.Fatalf("err = %v, want the readiness timeout with the last probe error", err)
+	}
+}
@@ -0,0 +1,84 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+)
+
+const manifestName = "bin/manifest.json"
+
+// manifestFS holds the build manifest written next to the binaries by make build.
+//
+//go:embed bin/manifest.json
+var manifestFS embed.FS
+
+// Manifest is the build information of the embedded desktop, from bin/manifest.json.
+type Manifest struct {
+	// Version is the desktop version.
+	Version string `json:"version"`
+	// GitSHA is the desktop commit the binary was built from.
+	GitSHA string `json:"git_sha"`
+	// BuildDate is the RFC 3339 UTC time of the build.
+	BuildDate string `json:"build_date"`
+	// CIRunURL links the CI run that produced the build, empty for local builds.
+	CIRunURL string `json:"ci_run_url"`
+	// Features lists the feature flags the desktop was built with.
+	Features []string `json:"features"`
+}
+
+var (
+	manifestOnce sync.Once
+	manifest     Manifest
+	manifestErr  error
+)
+
+// GetManifest returns the embedded build manifest. It is parsed on the first call
+// and cached; later calls return a copy of the cached result.
+func GetManifest() (Manifest, error) {
+	manifestOnce.Do(func() {
+		manifest, manifestErr = parseManifest(manifestFS)
+	})
+	m := manifest
+	m.Features = slices.Clone(manifest.Features)
+	return m, manifestErr
+}
+
+func parseManifest(fsys fs.FS) (Manifest, error) {
+	data, err := fs.ReadFile(fsys, manifestName)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("embedded: read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("embedded: parse %s: %w", manifestName, err)
+	}
+	return m, nil
+}
@@ -0,0 +1,94 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const manifestFixture = `{
+  "version": "1.4.2",
+  "git_sha": "3f2a9c1d8e7b6a5f4c3d2e1f0a9b8c7d6e5f4a3b",
+  "build_date": "2025-06-01T12:00:00Z",
+  "ci_run_url": "https://github.com/Warp-net/warpnet-desktop/actions/runs/1",
+  "features": ["tray", "pty"],
+  "unknown": true
+}`
+
+func TestParseManifest(t *testing.T) {
+	fsys := fstest.MapFS{manifestName: {Data: []byte(manifestFixture)}}
+	m, err := parseManifest(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Manifest{
+		Version:   "1.4.2",
+		GitSHA:    "3f2a9c1d8e7b6a5f4c3d2e1f0a9b8c7d6e5f4a3b",
+		BuildDate: "2025-06-01T12:00:00Z",
+		CIRunURL:  "https://github.com/Warp-net/warpnet-desktop/actions/runs/1",
+		Features:  []string{"tray", "pty"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("manifest = %+v, want %+v", m, want)
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	if _, err := parseManifest(fstest.MapFS{}); err == nil || !strings.Contains(err.Error(), "read manifest") {
+		t.Fatalf("err = %v, want a read error for a missing manifest", err)
+	}
+	fsys := fstest.MapFS{manifestName: {Data: []byte(`{"version": 1}`)}}
+	if _, err := parseManifest(fsys); err == nil || !strings.Contains(err.Error(), "parse "+manifestName) {
+		t.Fatalf("err = %v, want a parse error", err)
+	}
+}
+
+func TestGetManifestCached(t *testing.T) {
+	want, err := parseManifest(manifestFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := GetManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, want) {
+		t.Fatalf("manifest = %+v, want the embedded %+v", first, want)
+	}
+
+	// callers get copies, so changing one result doesn't leak into the cache
+	first.Version = "changed"
+	first.Features = append(first.Features, "changed")
+	second, err := GetManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(second, want) {
+		t.Fatalf("second manifest = %+v, want the cached %+v", second, want)
+	}
+}
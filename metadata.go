@@ -0,0 +1,53 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import "runtime"
+
+// Version is the version of the embedded desktop build. It is injected at build time:
+//
+//	go build -ldflags "-X github.com/Warp-net/warpnet-desktop.Version=<version>"
+var Version = "dev"
+
+// Metadata describes the embedded desktop binary.
+type Metadata struct {
+	GOOS    string
+	GOARCH  string
+	Version string
+	SHA256  string
+	Size    int64
+}
+
+// GetMetadata returns the description of the embedded desktop binary.
+// SHA256 is empty if the binary can't be decompressed.
+func GetMetadata() Metadata {
+	return Metadata{
+		GOOS:    runtime.GOOS,
+		GOARCH:  runtime.GOARCH,
+		Version: Version,
+		SHA256:  Checksum(),
+		Size:    Size(),
+	}
+}
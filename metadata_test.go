@@ -0,0 +1,56 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime"
+	"testing"
+)
+
+func TestGetMetadata(t *testing.T) {
+	requireBinary(t)
+	data, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+
+	md := GetMetadata()
+	want := Metadata{
+		GOOS:    runtime.GOOS,
+		GOARCH:  runtime.GOARCH,
+		Version: Version,
+		SHA256:  hex.EncodeToString(sum[:]),
+		Size:    int64(len(data)),
+	}
+	if md != want {
+		t.Fatalf("GetMetadata() = %+v, want %+v", md, want)
+	}
+	if md.Version == "" {
+		t.Fatal("Version is empty")
+	}
+}
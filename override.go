@@ -0,0 +1,91 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DesktopPathEnv names a desktop executable to use instead of the embedded
+// binary during desktop development, see AllowDesktopPathOverride.
+const DesktopPathEnv = "WARPNET_DESKTOP_PATH"
+
+// AllowDesktopPathOverride makes the package honor DesktopPathEnv: if it points at
+// an existing file, GetDesktopEmbeddedErr, Reader, ReaderAt, WriteTo, StreamTo and
+// Size read that file, and EnsureExtracted, and with it Command and the Launch
+// family, ephemeral launches included, return its path as is, so a rebuilt
+// desktop is picked up without re-embedding it. Resources aren't extracted next
+// to it. ExtractTo, Checksum, Verify and GetMetadata keep describing the
+// embedded binary, since they are about what ships.
+// It is meant for development builds only and is off by default, so the variable
+// has no effect in production. Every use is reported to the Logger, and the first
+// one is also printed to stderr. Set it before launching.
+var AllowDesktopPathOverride bool
+
+var overrideWarnOnce sync.Once
+
+// desktopOverride returns the path DesktopPathEnv points at, if the override is
+// allowed and the file exists, and reports its use.
+func desktopOverride() (string, bool) {
+	return defaultManager.desktopOverride()
+}
+
+func (m *Manager) desktopOverride() (string, bool) {
+	path, ok := m.lookupOverride()
+	if !ok {
+		return "", false
+	}
+	m.log("dev override", "path", path)
+	overrideWarnOnce.Do(func() {
+		fmt.Fprintf(os.Stderr, "embedded: WARNING: using desktop binary %s from %s instead of the embedded one\n", path, DesktopPathEnv)
+	})
+	return path, true
+}
+
+// overrideActive reports whether desktopOverride applies, without reporting it.
+func overrideActive() bool {
+	return defaultManager.overrideActive()
+}
+
+func (m *Manager) overrideActive() bool {
+	_, ok := m.lookupOverride()
+	return ok
+}
+
+func (m *Manager) lookupOverride() (string, bool) {
+	if !m.overrideAllowed() {
+		return "", false
+	}
+	path := os.Getenv(DesktopPathEnv)
+	if path == "" {
+		return "", false
+	}
+	if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return path, true
+}
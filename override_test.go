@@ -0,0 +1,151 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// allowOverride points DesktopPathEnv at a fixture binary and turns on
+// AllowDesktopPathOverride for the test.
+func allowOverride(t *testing.T) (path string, data []byte) {
+	t.Helper()
+	data = []byte("#!/bin/sh\necho dev build\n")
+	path = filepath.Join(t.TempDir(), "warpnet-desktop-dev")
+	if err := os.WriteFile(path, data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(DesktopPathEnv, path)
+	allowed := AllowDesktopPathOverride
+	AllowDesktopPathOverride = true
+	t.Cleanup(func() { AllowDesktopPathOverride = allowed })
+	return path, data
+}
+
+func TestDesktopPathOverride(t *testing.T) {
+	path, want := allowOverride(t)
+
+	getters := map[string]func() ([]byte, error){
+		"Err": GetDesktopEmbeddedErr,
+		"Ctx": func() ([]byte, error) { return GetDesktopEmbeddedCtx(context.Background()) },
+		"For": func() ([]byte, error) { return GetDesktopEmbeddedFor("") },
+	}
+	for name, get := range getters {
+		data, err := get()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Fatalf("%s returned %q, want the override fixture", name, data)
+		}
+	}
+
+	dir := t.TempDir()
+	extracted, err := EnsureExtracted(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extracted != path {
+		t.Fatalf("EnsureExtracted = %s, want %s", extracted, path)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("override extracted %v anyway", entries)
+	}
+}
+
+func TestDesktopPathOverrideStreams(t *testing.T) {
+	_, want := allowOverride(t)
+
+	if data, err := io.ReadAll(Reader()); err != nil || !bytes.Equal(data, want) {
+		t.Fatalf("Reader returned %q, %v; want the override fixture", data, err)
+	}
+	var buf bytes.Buffer
+	if _, err := WriteTo(&buf); err != nil || !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("WriteTo wrote %q, %v; want the override fixture", buf.Bytes(), err)
+	}
+	buf.Reset()
+	if _, err := StreamTo(context.Background(), &buf); err != nil || !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("StreamTo wrote %q, %v; want the override fixture", buf.Bytes(), err)
+	}
+	if size := Size(); size != int64(len(want)) {
+		t.Fatalf("Size = %d, want %d", size, len(want))
+	}
+	last := make([]byte, 1)
+	if n, err := ReaderAt().ReadAt(last, int64(len(want)-1)); n != 1 || err != nil || last[0] != want[len(want)-1] {
+		t.Fatalf("ReadAt of the last byte = %d, %q, %v", n, last, err)
+	}
+	if _, err := ReaderAt().ReadAt(last, int64(len(want))); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadAt past the end: %v, want io.EOF", err)
+	}
+}
+
+func TestDesktopPathOverrideEphemeral(t *testing.T) {
+	m := helperManager(t)
+	opts := helperOptions("echo-args")
+	opts.Ephemeral = true
+	p, err := m.LaunchWithOptions(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if path := p.Cmd().Path; path != testExecutable(t) {
+		t.Fatalf("ephemeral launch ran %s, want the override %s", path, testExecutable(t))
+	}
+}
+
+func TestDesktopPathOverrideReported(t *testing.T) {
+	path, _ := allowOverride(t)
+	log := &eventLog{}
+	m := newTestManager(t, WithLogger(log))
+	if got, err := m.ensureExtracted("", false); err != nil || got != path {
+		t.Fatalf("ensureExtracted = %s, %v; want %s", got, err, path)
+	}
+	if !log.has("dev override") {
+		t.Fatal("override use wasn't logged")
+	}
+}
+
+func TestDesktopPathOverrideIgnored(t *testing.T) {
+	path, _ := allowOverride(t)
+	if _, ok := NewManager(WithDesktopPathOverride(false)).lookupOverride(); ok {
+		t.Fatal("override applied although it isn't allowed")
+	}
+	t.Setenv(DesktopPathEnv, filepath.Join(filepath.Dir(path), "missing"))
+	if _, ok := NewManager(WithDesktopPathOverride(true)).lookupOverride(); ok {
+		t.Fatal("override applied for a missing file")
+	}
+	t.Setenv(DesktopPathEnv, filepath.Dir(path))
+	if _, ok := NewManager(WithDesktopPathOverride(true)).lookupOverride(); ok {
+		t.Fatal("override applied for a dir")
+	}
+}
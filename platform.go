@@ -0,0 +1,293 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// VerifyPlatformOnLaunch makes Command and the Launch family call VerifyPlatform
+// before starting the desktop. Set it before launching.
+var VerifyPlatformOnLaunch bool
+
+type binaryFormat string
+
+const (
+	formatUnknown binaryFormat = "unknown"
+	formatMachO   binaryFormat = "Mach-O"
+	formatELF     binaryFormat = "ELF"
+	formatPE      binaryFormat = "PE"
+)
+
+var (
+	magicELF   = []byte{0x7f, 'E', 'L', 'F'}
+	magicMZ    = []byte{'M', 'Z'}
+	magicFat   = []byte{0xca, 0xfe, 0xba, 0xbe}
+	magicFat64 = []byte{0xca, 0xfe, 0xba, 0xbf}
+
+	magicsMachO = [][]byte{
+		{0xfe, 0xed, 0xfa, 0xce}, // 32-bit, big endian
+		{0xfe, 0xed, 0xfa, 0xcf}, // 64-bit, big endian
+		{0xce, 0xfa, 0xed, 0xfe}, // 32-bit, little endian
+		{0xcf, 0xfa, 0xed, 0xfe}, // 64-bit, little endian
+		magicFat,                 // universal
+		magicFat64,               // universal with 64-bit offsets
+	}
+)
+
+// headerSize is how much of the binary VerifyPlatform reads, enough for the
+// Mach-O fat header and the PE header behind the DOS stub.
+const headerSize = 4 << 10
+
+// VerifyPlatform checks that the embedded desktop binary is an executable format
+// the running OS can load: Mach-O on darwin, PE on windows and ELF elsewhere,
+// built for runtime.GOARCH. It turns a bad cross-compiled embed into a descriptive
+// error instead of an "exec format error" at launch.
+//
+// A binary for another architecture that the OS runs under emulation or in a
+// compatibility mode, such as amd64 under Rosetta on Apple Silicon, passes with
+// a "platform warning" event to the Logger, since it works but runs slowly.
+// A 32-bit binary fails on darwin, which can't run those since macOS 10.15.
+func VerifyPlatform() error {
+	return defaultManager.VerifyPlatform()
+}
+
+// VerifyPlatform is the package-level VerifyPlatform reporting to the Manager's Logger.
+func (m *Manager) VerifyPlatform() error {
+	header, err := readHeader()
+	if err != nil {
+		return err
+	}
+	return m.verifyHeader(runtime.GOOS, runtime.GOARCH, header)
+}
+
+// verifyHeader is VerifyPlatform for a binary starting with header on goos/goarch.
+func (m *Manager) verifyHeader(goos, goarch string, header []byte) error {
+	want := platformFormat(goos)
+	got := detectFormat(header)
+	if got != want {
+		return fmt.Errorf("embedded: desktop binary is %s executable, %s requires %s", got, goos, want)
+	}
+
+	archs, err := binaryArchs(got, header)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(archs, goarch) {
+		return nil
+	}
+	for _, arch := range archs {
+		if runsEmulated(goos, goarch, arch) {
+			m.log("platform warning", "binary_arch", arch, "goarch", goarch, "emulated", true)
+			return nil
+		}
+	}
+	return fmt.Errorf("embedded: desktop binary is built for %s, %s/%s can't run it",
+		strings.Join(archs, ", "), goos, goarch)
+}
+
+// readHeader returns the first headerSize bytes of the decompressed binary, or
+// all of it if it is shorter.
+func readHeader() ([]byte, error) {
+	header := make([]byte, headerSize)
+	n, err := ReaderAt().ReadAt(header, 0)
+	if err != nil && (n == 0 || !errors.Is(err, io.EOF)) {
+		return nil, fmt.Errorf("embedded: read desktop binary header: %w", err)
+	}
+	return header[:n], nil
+}
+
+// preLaunchChecks runs the checks enabled for every launch.
+func (m *Manager) preLaunchChecks() error {
+	if m.verifyPlatformOnLaunch() {
+		return m.VerifyPlatform()
+	}
+	return nil
+}
+
+func platformFormat(goos string) binaryFormat {
+	switch goos {
+	case "darwin", "ios":
+		return formatMachO
+	case "windows":
+		return formatPE
+	default:
+		return formatELF
+	}
+}
+
+func detectFormat(header []byte) binaryFormat {
+	switch {
+	case bytes.HasPrefix(header, magicELF):
+		return formatELF
+	case bytes.HasPrefix(header, magicMZ):
+		return formatPE
+	}
+	for _, magic := range magicsMachO {
+		if bytes.HasPrefix(header, magic) {
+			return formatMachO
+		}
+	}
+	return formatUnknown
+}
+
+// binaryArchs decodes the CPU types in the header as GOARCH names. A universal
+// Mach-O binary yields one per slice; unknown CPU types are named by their value.
+func binaryArchs(format binaryFormat, header []byte) ([]string, error) {
+	errShort := fmt.Errorf("embedded: desktop binary %s header is truncated", format)
+	switch format {
+	case formatMachO:
+		if bytes.HasPrefix(header, magicFat) || bytes.HasPrefix(header, magicFat64) {
+			if len(header) < 8 {
+				return nil, errShort
+			}
+			// fat_arch is 20 bytes, fat_arch_64 32 with its 64-bit offset and size
+			stride := 20
+			if bytes.HasPrefix(header, magicFat64) {
+				stride = 32
+			}
+			n := binary.BigEndian.Uint32(header[4:])
+			var archs []string
+			for i := uint32(0); i < n; i++ {
+				off := 8 + int(i)*stride
+				if len(header) < off+4 {
+					return nil, errShort
+				}
+				archs = append(archs, machoArch(macho.Cpu(binary.BigEndian.Uint32(header[off:]))))
+			}
+			return archs, nil
+		}
+		if len(header) < 8 {
+			return nil, errShort
+		}
+		order := binary.ByteOrder(binary.BigEndian)
+		if header[0] == 0xce || header[0] == 0xcf {
+			order = binary.LittleEndian
+		}
+		return []string{machoArch(macho.Cpu(order.Uint32(header[4:])))}, nil
+
+	case formatELF:
+		if len(header) < 20 {
+			return nil, errShort
+		}
+		order := binary.ByteOrder(binary.LittleEndian)
+		if elf.Data(header[elf.EI_DATA]) == elf.ELFDATA2MSB {
+			order = binary.BigEndian
+		}
+		return []string{elfArch(elf.Machine(order.Uint16(header[18:])))}, nil
+
+	case formatPE:
+		if len(header) < 0x40 {
+			return nil, errShort
+		}
+		off := int(binary.LittleEndian.Uint32(header[0x3c:]))
+		if off < 0 || len(header) < off+6 {
+			return nil, errShort
+		}
+		if !bytes.Equal(header[off:off+4], []byte("PE\x00\x00")) {
+			return nil, errors.New("embedded: desktop binary has no PE signature")
+		}
+		return []string{peArch(binary.LittleEndian.Uint16(header[off+4:]))}, nil
+	}
+	return nil, fmt.Errorf("embedded: can't decode the architecture of a %s binary", format)
+}
+
+func machoArch(cpu macho.Cpu) string {
+	switch cpu {
+	case macho.CpuAmd64:
+		return "amd64"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.Cpu386:
+		return "386"
+	case macho.CpuArm:
+		return "arm"
+	}
+	return fmt.Sprintf("cpu %#x", uint32(cpu))
+}
+
+func elfArch(machine elf.Machine) string {
+	switch machine {
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_386:
+		return "386"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_RISCV:
+		return "riscv64"
+	case elf.EM_PPC64:
+		return "ppc64le"
+	case elf.EM_S390:
+		return "s390x"
+	case elf.EM_LOONGARCH:
+		return "loong64"
+	}
+	return machine.String()
+}
+
+func peArch(machine uint16) string {
+	switch machine {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386"
+	case pe.IMAGE_FILE_MACHINE_ARMNT:
+		return "arm"
+	}
+	return fmt.Sprintf("machine %#x", machine)
+}
+
+// runsEmulated reports whether goos on goarch can run a binary built for arch
+// through emulation or a compatibility mode.
+func runsEmulated(goos, goarch, arch string) bool {
+	switch {
+	case goos == "darwin" && goarch == "arm64":
+		return arch == "amd64" // Rosetta 2
+	case goos == "darwin":
+		return false // 32-bit binaries stopped running with macOS 10.15
+	case goos == "windows" && goarch == "arm64":
+		return arch == "amd64" || arch == "386"
+	case goarch == "amd64":
+		return arch == "386" // WOW64 on windows, multilib on linux
+	case goarch == "arm64" && goos != "darwin":
+		return arch == "arm"
+	}
+	return false
+}
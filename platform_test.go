@@ -0,0 +1,150 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"testing"
+)
+
+func elfHeader(machine elf.Machine) []byte {
+	h := make([]byte, 64)
+	copy(h, magicELF)
+	h[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	h[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	binary.LittleEndian.PutUint16(h[18:], uint16(machine))
+	return h
+}
+
+func machoHeader(cpu macho.Cpu) []byte {
+	h := make([]byte, 32)
+	magic := uint32(macho.Magic64)
+	if cpu == macho.Cpu386 {
+		magic = macho.Magic32
+	}
+	binary.LittleEndian.PutUint32(h, magic)
+	binary.LittleEndian.PutUint32(h[4:], uint32(cpu))
+	return h
+}
+
+// fatHeader is a universal Mach-O header with a slice per cpu, in the fat64
+// layout with 64-bit offsets if wide is set.
+func fatHeader(wide bool, cpus ...macho.Cpu) []byte {
+	magic, stride := uint32(macho.MagicFat), 20
+	if wide {
+		magic, stride = 0xcafebabf, 32
+	}
+	h := make([]byte, 8+len(cpus)*stride)
+	binary.BigEndian.PutUint32(h, magic)
+	binary.BigEndian.PutUint32(h[4:], uint32(len(cpus)))
+	for i, cpu := range cpus {
+		binary.BigEndian.PutUint32(h[8+i*stride:], uint32(cpu))
+	}
+	return h
+}
+
+func peHeader(machine uint16) []byte {
+	h := make([]byte, 0x80)
+	copy(h, magicMZ)
+	binary.LittleEndian.PutUint32(h[0x3c:], 0x40)
+	copy(h[0x40:], "PE\x00\x00")
+	binary.LittleEndian.PutUint16(h[0x44:], machine)
+	return h
+}
+
+func TestVerifyHeader(t *testing.T) {
+	const (
+		pass = iota
+		warn
+		fail
+	)
+	tests := []struct {
+		name         string
+		goos, goarch string
+		header       []byte
+		want         int
+	}{
+		{"ELF amd64 on linux/amd64", "linux", "amd64", elfHeader(elf.EM_X86_64), pass},
+		{"ELF arm64 on linux/amd64", "linux", "amd64", elfHeader(elf.EM_AARCH64), fail},
+		{"ELF 386 on linux/amd64", "linux", "amd64", elfHeader(elf.EM_386), warn},
+		{"ELF arm on linux/arm64", "linux", "arm64", elfHeader(elf.EM_ARM), warn},
+		{"Mach-O on linux", "linux", "amd64", machoHeader(macho.CpuAmd64), fail},
+		{"PE on linux", "linux", "amd64", peHeader(pe.IMAGE_FILE_MACHINE_AMD64), fail},
+		{"unknown format", "linux", "amd64", []byte("#!/bin/sh\n"), fail},
+
+		{"Mach-O amd64 on darwin/amd64", "darwin", "amd64", machoHeader(macho.CpuAmd64), pass},
+		{"Mach-O arm64 on darwin/arm64", "darwin", "arm64", machoHeader(macho.CpuArm64), pass},
+		{"Mach-O amd64 on darwin/arm64", "darwin", "arm64", machoHeader(macho.CpuAmd64), warn},
+		{"Mach-O arm64 on darwin/amd64", "darwin", "amd64", machoHeader(macho.CpuArm64), fail},
+		{"Mach-O 386 on darwin/amd64", "darwin", "amd64", machoHeader(macho.Cpu386), fail},
+		{"fat amd64+arm64 on darwin/arm64", "darwin", "arm64", fatHeader(false, macho.CpuAmd64, macho.CpuArm64), pass},
+		{"fat64 amd64+arm64 on darwin/arm64", "darwin", "arm64", fatHeader(true, macho.CpuAmd64, macho.CpuArm64), pass},
+		{"fat64 amd64 on darwin/arm64", "darwin", "arm64", fatHeader(true, macho.CpuAmd64), warn},
+		{"truncated fat", "darwin", "arm64", fatHeader(false, macho.CpuAmd64, macho.CpuArm64)[:20], fail},
+		{"ELF on darwin", "darwin", "arm64", elfHeader(elf.EM_AARCH64), fail},
+
+		{"PE amd64 on windows/amd64", "windows", "amd64", peHeader(pe.IMAGE_FILE_MACHINE_AMD64), pass},
+		{"PE arm64 on windows/amd64", "windows", "amd64", peHeader(pe.IMAGE_FILE_MACHINE_ARM64), fail},
+		{"PE 386 on windows/amd64", "windows", "amd64", peHeader(pe.IMAGE_FILE_MACHINE_I386), warn},
+		{"PE amd64 on windows/arm64", "windows", "arm64", peHeader(pe.IMAGE_FILE_MACHINE_AMD64), warn},
+		{"PE without signature", "windows", "amd64", append([]byte("MZ"), make([]byte, 0x7e)...), fail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &eventLog{}
+			err := NewManager(WithLogger(events)).verifyHeader(tt.goos, tt.goarch, tt.header)
+			got := pass
+			switch {
+			case err != nil:
+				got = fail
+			case events.has("platform warning"):
+				got = warn
+			}
+			if got != tt.want {
+				t.Fatalf("got %v (err %v), want %v", []string{"pass", "warning", "error"}[got], err, []string{"pass", "warning", "error"}[tt.want])
+			}
+		})
+	}
+}
+
+func TestBinaryArchsFat64(t *testing.T) {
+	archs, err := binaryArchs(formatMachO, fatHeader(true, macho.CpuArm64, macho.CpuAmd64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archs) != 2 || archs[0] != "arm64" || archs[1] != "amd64" {
+		t.Fatalf("archs = %v, want [arm64 amd64]", archs)
+	}
+}
+
+func TestVerifyPlatformEmbedded(t *testing.T) {
+	requireRunnableBinary(t)
+	if err := VerifyPlatform(); err != nil {
+		t.Fatal(err)
+	}
+}
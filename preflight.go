@@ -0,0 +1,85 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Preflight checks that the desktop could be launched from dir without launching
+// it: the embedded binary matches the platform and its checksum, dir has room for
+// it, and a test extraction into a temp dir inside dir succeeds and hashes correctly.
+// The temp dir is removed afterwards. An empty dir means ExtractDir().
+//
+// The first failure is returned, wrapped with the name of the check that failed.
+func Preflight(dir string) (err error) {
+	return defaultManager.Preflight(dir)
+}
+
+// Preflight is the package-level Preflight; an empty dir means the Manager's
+// extraction dir.
+func (m *Manager) Preflight(dir string) (err error) {
+	if dir == "" {
+		if dir, err = m.ExtractDir(); err != nil {
+			return fmt.Errorf("embedded: preflight: %w", err)
+		}
+	}
+
+	checks := []struct {
+		name string
+		run  func() error
+	}{
+		{"platform", m.VerifyPlatform},
+		{"checksum", Verify},
+		{"disk space", func() error { return CheckDiskSpace(dir) }},
+		{"extraction", func() error { return preflightExtract(dir) }},
+	}
+	for _, check := range checks {
+		if err := check.run(); err != nil {
+			return fmt.Errorf("embedded: preflight %s: %w", check.name, err)
+		}
+	}
+	return nil
+}
+
+func preflightExtract(dir string) error {
+	tmp, err := os.MkdirTemp(dir, ".preflight-*")
+	if err != nil {
+		return fmt.Errorf("embedded: create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	sum, err := checksum()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(tmp, binaryName)
+	if _, err := extractFile(path, nil, ""); err != nil {
+		return err
+	}
+	return verifyFile(path, sum)
+}
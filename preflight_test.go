@@ -0,0 +1,60 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPreflight(t *testing.T) {
+	requireRunnableBinary(t)
+	dir := t.TempDir()
+	if err := newTestManager(t).Preflight(dir); err != nil {
+		t.Fatal(err)
+	}
+	// the test extraction is removed again
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("preflight left %v behind", entries)
+	}
+}
+
+func TestPreflightNamesFailedCheck(t *testing.T) {
+	requireRunnableBinary(t)
+	// a file where the dir should be lets every check but the extraction pass
+	dir := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(dir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := newTestManager(t).Preflight(dir)
+	if err == nil {
+		t.Fatal("preflight passed for a dir that is a file")
+	}
+	if !strings.HasPrefix(err.Error(), "embedded: preflight extraction: ") {
+		t.Fatalf("err = %v, want it to name the extraction check", err)
+	}
+}
@@ -0,0 +1,154 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Process is a started desktop process. It waits for the process once in the
+// background, so any number of goroutines can watch it through Done without
+// racing on exec.Cmd.Wait.
+type Process struct {
+	pid int
+	// cmd is nil for processes that aren't children of the launcher, see
+	// LaunchOptions.Elevated.
+	cmd       *exec.Cmd
+	terminate func() error
+	kill      func() error
+
+	done chan struct{}
+	code int
+	err  error
+}
+
+// newProcess takes ownership of waiting for the started cmd. Its exit is
+// reported to log.
+func newProcess(cmd *exec.Cmd, log func(event string, kv ...any)) *Process {
+	p := &Process{
+		pid:       cmd.Process.Pid,
+		cmd:       cmd,
+		terminate: func() error { return terminate(cmd.Process) },
+		kill:      cmd.Process.Kill,
+		done:      make(chan struct{}),
+	}
+	go p.wait(func() (int, error) {
+		err := cmd.Wait()
+		return cmd.ProcessState.ExitCode(), err
+	}, log)
+	return p
+}
+
+// newForeignProcess tracks a process that os/exec didn't start, through
+// platform-specific wait, terminate and kill.
+func newForeignProcess(pid int, wait func() (int, error), terminate, kill func() error, log func(event string, kv ...any)) *Process {
+	p := &Process{
+		pid:       pid,
+		terminate: terminate,
+		kill:      kill,
+		done:      make(chan struct{}),
+	}
+	go p.wait(wait, log)
+	return p
+}
+
+func (p *Process) wait(wait func() (int, error), log func(event string, kv ...any)) {
+	p.code, p.err = wait()
+	log("child exited", "pid", p.pid, "code", p.code)
+	close(p.done)
+}
+
+// Cmd returns the underlying command, e.g. to inspect Path or ProcessState
+// after Done. The caller must not call Wait on it. It is nil for an elevated
+// process, which isn't started through os/exec.
+func (p *Process) Cmd() *exec.Cmd {
+	return p.cmd
+}
+
+// PID returns the process ID.
+func (p *Process) PID() int {
+	return p.pid
+}
+
+// Done returns a channel that is closed once the process has exited and its
+// input and output have been copied.
+func (p *Process) Done() <-chan struct{} {
+	return p.done
+}
+
+// ExitCode returns the exit code of the process once Done is closed, and -1
+// before that or if the process was killed by a signal.
+func (p *Process) ExitCode() int {
+	select {
+	case <-p.done:
+		return p.code
+	default:
+		return -1
+	}
+}
+
+// Wait blocks until the process exits and returns the result of exec.Cmd.Wait.
+// It can be called any number of times, from any goroutine.
+func (p *Process) Wait() error {
+	<-p.done
+	return p.err
+}
+
+// Kill kills the process and waits for it to exit.
+func (p *Process) Kill() error {
+	if err := p.kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("embedded: kill: %w", err)
+	}
+	<-p.done
+	return nil
+}
+
+// Shutdown asks the process to exit and kills it if it's still running after
+// grace, see the package-level Shutdown. It returns nil if the process exited
+// within grace.
+func (p *Process) Shutdown(grace time.Duration) error {
+	if err := p.terminate(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		_ = p.kill()
+		return waitErr(p.Wait())
+	}
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	select {
+	case <-p.done:
+		return waitErr(p.err)
+	case <-timer.C:
+	}
+	if err := p.kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("embedded: shutdown: kill: %w", err)
+	}
+	<-p.done
+	return fmt.Errorf("embedded: shutdown: process %d didn't exit within %s, killed", p.PID(), grace)
+}
@@ -0,0 +1,127 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Prune removes versioned binaries installed by Update from ExtractDir, with their
+// sidecars, once they are older than maxAge. ContentAddressed dirs count as
+// versions too. The keepLast newest versions are kept
+// regardless of age, and so is the active version: the one the stable name points
+// at and the one currently embedded. It returns how many versions were removed.
+// A version that can't be removed, such as one still running on Windows, is
+// skipped and left for the next call.
+func Prune(maxAge time.Duration, keepLast int) (removed int, err error) {
+	return defaultManager.Prune(maxAge, keepLast)
+}
+
+func prune(dir string, maxAge time.Duration, keepLast int) (removed int, err error) {
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = fmt.Errorf("embedded: unlock extraction dir: %w", unlockErr)
+		}
+	}()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("embedded: prune: %w", err)
+	}
+	type version struct {
+		name    string
+		modTime time.Time
+	}
+	var versions []version
+	for _, e := range entries {
+		if !isVersionedName(e.Name()) && !(e.IsDir() && isContentDirName(e.Name())) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version{e.Name(), info.ModTime()})
+	}
+	slices.SortFunc(versions, func(a, b version) int {
+		return b.modTime.Compare(a.modTime)
+	})
+
+	active := activeVersions(dir)
+	for i, v := range versions {
+		if i < keepLast || slices.Contains(active, v.name) || time.Since(v.modTime) < maxAge {
+			continue
+		}
+		if isContentDirName(v.name) {
+			sub := filepath.Join(dir, v.name)
+			if err := cleanupDir(sub); err != nil {
+				continue
+			}
+			if _, err := os.Stat(sub); errors.Is(err, fs.ErrNotExist) {
+				removed++
+			}
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, v.name)); err != nil {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, v.name+checksumSuffix))
+		removed++
+	}
+	return removed, nil
+}
+
+// activeVersions returns the versioned names Prune must keep: the stable
+// symlink target, the version recorded in the stable sidecar (for hard links and
+// copies) and the embedded version, both as a versioned file and a content dir.
+func activeVersions(dir string) []string {
+	stable := filepath.Join(dir, binaryName)
+	var active []string
+	if name := currentVersion(stable); name != "" {
+		active = append(active, name)
+	}
+	if data, err := os.ReadFile(stable + checksumSuffix); err == nil {
+		if sum, err := parseChecksum(data); err == nil {
+			active = append(active, versionedName(sum))
+		}
+	}
+	if sum, err := checksum(); err == nil {
+		active = append(active, versionedName(sum), contentDirName(sum))
+	}
+	return active
+}
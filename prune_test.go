@@ -0,0 +1,148 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// seedVersion creates the versioned binary and sidecar Update would install
+// for the digest of repeated c, last modified age ago, and returns its name.
+func seedVersion(t *testing.T, dir string, c string, age time.Duration) string {
+	t.Helper()
+	sum := strings.Repeat(c, 64)
+	name := versionedName(sum)
+	writeAged(t, filepath.Join(dir, name), age)
+	writeAged(t, filepath.Join(dir, name+checksumSuffix), age)
+	return name
+}
+
+func writeAged(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(filepath.Base(path)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exists(t *testing.T, path string) bool {
+	t.Helper()
+	_, err := os.Lstat(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+	return err == nil
+}
+
+func TestPrune(t *testing.T) {
+	m := newTestManager(t)
+	dir := m.dir
+	newest := seedVersion(t, dir, "1", time.Hour)
+	old := seedVersion(t, dir, "2", 2*time.Hour)
+	active := seedVersion(t, dir, "3", 3*time.Hour)
+	older := seedVersion(t, dir, "4", 4*time.Hour)
+	oldest := seedVersion(t, dir, "5", 5*time.Hour)
+
+	content := filepath.Join(dir, contentDirName(strings.Repeat("6", 64)))
+	if err := os.Mkdir(content, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeAged(t, filepath.Join(content, binaryName), 6*time.Hour)
+	mtime := time.Now().Add(-6 * time.Hour)
+	if err := os.Chtimes(content, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stable sidecar marks version 3 as the one in use
+	if err := os.WriteFile(filepath.Join(dir, binaryName+checksumSuffix), []byte(strings.Repeat("3", 64)+"  "+binaryName+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	foreign := filepath.Join(dir, "notes.txt")
+	writeAged(t, foreign, 24*time.Hour)
+
+	removed, err := m.Prune(90*time.Minute, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 4 {
+		t.Errorf("removed = %d, want 4", removed)
+	}
+	for _, name := range []string{newest, active} {
+		if !exists(t, filepath.Join(dir, name)) || !exists(t, filepath.Join(dir, name+checksumSuffix)) {
+			t.Errorf("%s or its sidecar was pruned", name)
+		}
+	}
+	for _, name := range []string{old, older, oldest} {
+		if exists(t, filepath.Join(dir, name)) || exists(t, filepath.Join(dir, name+checksumSuffix)) {
+			t.Errorf("%s or its sidecar survived", name)
+		}
+	}
+	if exists(t, content) {
+		t.Error("old content dir survived")
+	}
+	if !exists(t, foreign) {
+		t.Error("a file prune doesn't own was removed")
+	}
+}
+
+func TestPruneKeepsYoungVersions(t *testing.T) {
+	m := newTestManager(t)
+	names := []string{
+		seedVersion(t, m.dir, "a", time.Minute),
+		seedVersion(t, m.dir, "b", 2*time.Minute),
+	}
+	removed, err := m.Prune(time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 0 {
+		t.Errorf("removed = %d, want 0", removed)
+	}
+	for _, name := range names {
+		if !exists(t, filepath.Join(m.dir, name)) {
+			t.Errorf("%s is younger than maxAge but was pruned", name)
+		}
+	}
+}
+
+func TestPruneMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "never-extracted")
+	removed, err := NewManager(WithExtractDir(dir)).Prune(0, 0)
+	if err != nil || removed != 0 {
+		t.Fatalf("Prune = %d, %v; want 0, nil", removed, err)
+	}
+	if exists(t, dir) {
+		t.Fatal("prune created the extraction dir")
+	}
+}
@@ -1,5 +1,5 @@
-//go:build windows
-// +build windows
+//go:build !linux && !darwin
+// +build !linux,!darwin
 
 /* License generated by licensor(https://github.com/Marvin9/licensor).
 
@@ -28,15 +28,12 @@ resulting from the use or misuse of this software.
 package embedded
 
 import (
-	_ "embed"
-	_ "github.com/Warp-net/warpnet-desktop/bin"
+	"os"
+	"os/exec"
 )
 
-// This file embeds the whole desktop to a single Golang binary file. DO NOT REMOVE.
-//
-//go:embed bin/warpnet-desktop-windows
-var desktopBinary []byte
-
-func GetDesktopEmbedded() []byte {
-	return desktopBinary
+func openPTY() (master, slave *os.File, err error) {
+	return nil, nil, ErrPTYUnsupported
 }
+
+func setControllingTTY(_ *exec.Cmd) {}
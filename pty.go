@@ -0,0 +1,79 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// ErrPTYUnsupported is returned by LaunchPTY on platforms without pseudo-terminals.
+var ErrPTYUnsupported = errors.New("embedded: pty launch is only supported on linux and darwin")
+
+// LaunchPTY is Launch with the desktop's stdin, stdout and stderr attached to a
+// new pseudo-terminal, which also becomes its controlling terminal, so it
+// behaves as it would in an interactive shell. The master side is returned for
+// the caller to read the output from and write input to. It is closed once the
+// process exits, so reads fail from then on and the fds don't leak.
+//
+// It returns ErrPTYUnsupported outside linux and darwin.
+func LaunchPTY(ctx context.Context, args ...string) (*Process, *os.File, error) {
+	return defaultManager.LaunchPTY(ctx, args...)
+}
+
+// LaunchPTY is the package-level LaunchPTY using the Manager's settings.
+func (m *Manager) LaunchPTY(ctx context.Context, args ...string) (*Process, *os.File, error) {
+	opts := LaunchOptions{Args: args}
+	if err := m.checkLaunch(opts); err != nil {
+		return nil, nil, err
+	}
+	master, slave, err := openPTY()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the child holds its own copy of the slave once started
+	defer slave.Close()
+
+	path, _, err := m.prepare(opts, m.verifyCachedBeforeLaunch())
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	cmd := m.command(ctx, path, opts)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	setControllingTTY(cmd)
+
+	p, err := m.startCommand(cmd, 0, path)
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	go func() {
+		<-p.Done()
+		_ = master.Close()
+	}()
+	return p, master, nil
+}
@@ -0,0 +1,87 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	// ReadinessPollInterval is how often LaunchAndWaitReady runs the probe, see
+	// WithReadinessPollInterval.
+	ReadinessPollInterval = 250 * time.Millisecond
+	// ReadinessTimeout bounds how long LaunchAndWaitReady waits for the probe
+	// to pass, on top of the deadline of its context. Zero disables it. See
+	// WithReadinessTimeout.
+	ReadinessTimeout = 30 * time.Second
+)
+
+// LaunchAndWaitReady is Launch that returns only once the desktop is ready:
+// it runs probe every ReadinessPollInterval until it returns nil. If ctx is done
+// or ReadinessTimeout passes first, the process is killed and the last probe
+// error is returned. The probe should return quickly once its context is done.
+//
+// As with Launch, ctx also bounds the lifetime of the process.
+func LaunchAndWaitReady(ctx context.Context, probe func(context.Context) error, args ...string) (*Process, error) {
+	return defaultManager.LaunchAndWaitReady(ctx, probe, args...)
+}
+
+// LaunchAndWaitReady is the package-level LaunchAndWaitReady using the Manager's settings.
+func (m *Manager) LaunchAndWaitReady(ctx context.Context, probe func(context.Context) error, args ...string) (*Process, error) {
+	p, err := m.Launch(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.waitReady(ctx, probe); err != nil {
+		_ = p.Kill()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (m *Manager) waitReady(ctx context.Context, probe func(context.Context) error) error {
+	if timeout := m.readinessTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(m.readinessPollInterval())
+	defer ticker.Stop()
+
+	for {
+		err := probe(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("embedded: desktop is not ready: %w (last probe error: %v)", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,269 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"cmp"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// signatureSuffix names the detached ed25519 signature of a remote sidecar.
+	signatureSuffix = ".sig"
+	// maxRemoteSize caps a remote binary, so a misbehaving server can't fill the disk.
+	maxRemoteSize = 2 << 30
+	// maxSidecarSize caps the remote sidecar and its signature.
+	maxSidecarSize = 4 << 10
+)
+
+// remoteName is the file a remote override is downloaded to in the extraction dir.
+var remoteName = strings.TrimSuffix(binaryName, filepath.Ext(binaryName)) + ".remote" + filepath.Ext(binaryName)
+
+// LaunchWithRemoteOverride is Launch with a hotfix build fetched from url run
+// instead of the embedded binary. Next to the binary the server provides
+// url.sha256 and url.sha256.sig. The first line of url.sha256 is the sha256sum
+// output of the binary, a later one reads "version <version>". url.sha256.sig
+// is the raw 64-byte ed25519 signature, made with the key matching pubkey, of
+// the message
+//
+//	warpnet-desktop remote override\n<url>\n<contents of url.sha256>
+//
+// so a signature is bound to the URL and version it was made for. The version
+// must be newer than the embedded Version, so an old but validly signed build
+// can't be replayed to force a downgrade; a Version that isn't a dotted
+// numeric version, like "dev", accepts no remote at all. The binary is
+// downloaded into ExtractDir and only renamed to its final name once its hash
+// matches the signed one.
+//
+// The embedded binary stays the default: if the download, the signature, the
+// version or the checksum fails, or the downloaded binary can't be started, the
+// failure is reported to the Logger as "remote override failed" and the
+// embedded binary is launched as Launch does.
+func LaunchWithRemoteOverride(ctx context.Context, url string, pubkey ed25519.PublicKey, args ...string) (*Process, error) {
+	return defaultManager.LaunchWithRemoteOverride(ctx, url, pubkey, args...)
+}
+
+// LaunchWithRemoteOverride is the package-level LaunchWithRemoteOverride using
+// the Manager's settings.
+func (m *Manager) LaunchWithRemoteOverride(ctx context.Context, url string, pubkey ed25519.PublicKey, args ...string) (*Process, error) {
+	opts := LaunchOptions{Args: args}
+	if err := m.checkLaunch(opts); err != nil {
+		return nil, err
+	}
+
+	path, version, err := m.fetchRemote(ctx, url, pubkey)
+	if required, teamID := m.signatureRequired(); err == nil && required {
+		err = verifySignature(path, teamID)
+	}
+	if err == nil && m.extractResourcesOnLaunch() {
+		err = ExtractResources(filepath.Dir(path))
+	}
+	if err == nil {
+		m.log("remote override", "url", url, "path", path, "version", version)
+		var p *Process
+		if p, err = m.launch(ctx, path, func() {}, opts); err == nil {
+			return p, nil
+		}
+	}
+	m.log("remote override failed", "url", url, "err", err)
+	return m.LaunchWithOptions(ctx, opts)
+}
+
+// fetchRemote downloads the binary at url into the extraction dir after
+// checking the signature and version of its sidecar, and returns its path and
+// version.
+func (m *Manager) fetchRemote(ctx context.Context, url string, pubkey ed25519.PublicKey) (path, version string, err error) {
+	if len(pubkey) != ed25519.PublicKeySize {
+		return "", "", fmt.Errorf("embedded: remote override: public key is %d bytes, want %d", len(pubkey), ed25519.PublicKeySize)
+	}
+	sums, err := fetchSmall(ctx, url+checksumSuffix)
+	if err != nil {
+		return "", "", err
+	}
+	sig, err := fetchSmall(ctx, url+checksumSuffix+signatureSuffix)
+	if err != nil {
+		return "", "", err
+	}
+	if !ed25519.Verify(pubkey, remoteMessage(url, sums), sig) {
+		return "", "", errors.New("embedded: remote override: bad signature of " + url + checksumSuffix)
+	}
+	expected, err := parseChecksum(sums)
+	if err != nil {
+		return "", "", fmt.Errorf("embedded: remote override: %w", err)
+	}
+	version, err = remoteVersion(sums)
+	if err != nil {
+		return "", "", fmt.Errorf("embedded: remote override: %s: %w", url+checksumSuffix, err)
+	}
+	newer, err := compareVersions(version, Version)
+	if err != nil {
+		return "", "", fmt.Errorf("embedded: remote override: %w", err)
+	}
+	if newer <= 0 {
+		return "", "", fmt.Errorf("embedded: remote override: version %s isn't newer than the embedded %s", version, Version)
+	}
+
+	dir, err := m.ExtractDir()
+	if err != nil {
+		return "", "", err
+	}
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = fmt.Errorf("embedded: unlock extraction dir: %w", unlockErr)
+		}
+	}()
+	path = filepath.Join(dir, remoteName)
+	if err := checkInside(dir, path); err != nil {
+		return "", "", err
+	}
+
+	body, err := get(ctx, url)
+	if err != nil {
+		return "", "", err
+	}
+	defer body.Close()
+	h := sha256.New()
+	src := io.TeeReader(io.LimitReader(body, maxRemoteSize), h)
+	verify := func() error {
+		if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+			return fmt.Errorf("embedded: remote override: %s checksum mismatch: expected %s, actual %s", url, expected, actual)
+		}
+		return nil
+	}
+	if err := writeAtomicVerified(path, src, binaryMode, verify); err != nil {
+		return "", "", err
+	}
+	if err := clearQuarantine(path); err != nil {
+		return "", "", fmt.Errorf("embedded: clear quarantine: %w", err)
+	}
+	return path, version, nil
+}
+
+// remoteMessage is what the signature of a remote sidecar is made over.
+func remoteMessage(url string, sums []byte) []byte {
+	return append([]byte("warpnet-desktop remote override\n"+url+"\n"), sums...)
+}
+
+// remoteVersion returns the version a remote sidecar declares.
+func remoteVersion(sums []byte) (string, error) {
+	for _, line := range strings.Split(string(sums), "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), "version "); ok && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v), nil
+		}
+	}
+	return "", errors.New("no version line")
+}
+
+// compareVersions compares dotted numeric versions like 1.4.2, with an
+// optional v prefix and -prerelease suffix, and returns -1, 0 or +1. A
+// prerelease sorts before the release it precedes.
+func compareVersions(a, b string) (int, error) {
+	ca, pa, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	cb, pb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range max(len(ca), len(cb)) {
+		var x, y int
+		if i < len(ca) {
+			x = ca[i]
+		}
+		if i < len(cb) {
+			y = cb[i]
+		}
+		if x != y {
+			return cmp.Compare(x, y), nil
+		}
+	}
+	switch {
+	case pa == pb:
+		return 0, nil
+	case pa == "":
+		return 1, nil
+	case pb == "":
+		return -1, nil
+	}
+	return strings.Compare(pa, pb), nil
+}
+
+func parseVersion(v string) (core []int, pre string, err error) {
+	s, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), "+")
+	s, pre, _ = strings.Cut(s, "-")
+	for _, f := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return nil, "", fmt.Errorf("version %q isn't a dotted numeric version", v)
+		}
+		core = append(core, n)
+	}
+	return core, pre, nil
+}
+
+// fetchSmall downloads a sidecar or signature.
+func fetchSmall(ctx context.Context, url string) ([]byte, error) {
+	body, err := get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxSidecarSize))
+	if err != nil {
+		return nil, fmt.Errorf("embedded: remote override: read %s: %w", url, err)
+	}
+	return data, nil
+}
+
+func get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("embedded: remote override: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedded: remote override: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("embedded: remote override: GET %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
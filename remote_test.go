@@ -0,0 +1,203 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// remoteRelease describes what remoteServer publishes.
+type remoteRelease struct {
+	// version is declared in the sidecar.
+	version string
+	// sums, if set, replaces the sidecar that is served and signed.
+	sums []byte
+	// signedURL, if set, is the URL the signature is made for instead of the
+	// one the release is served from.
+	signedURL string
+}
+
+// remoteServer serves the test binary as a remote override under /desktop,
+// with a sidecar and a signature made by the returned key.
+func remoteServer(t *testing.T, rel remoteRelease) (url string, pubkey ed25519.PublicKey) {
+	t.Helper()
+	binary, err := os.ReadFile(testExecutable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(binary)
+	sums := append(sidecar(hex.EncodeToString(sum[:]), "desktop"), "version "+rel.version+"\n"...)
+	if rel.sums != nil {
+		sums = rel.sums
+	}
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	url = srv.URL + "/desktop"
+	signedURL := url
+	if rel.signedURL != "" {
+		signedURL = rel.signedURL
+	}
+	mux.HandleFunc("/desktop", func(w http.ResponseWriter, _ *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/desktop"+checksumSuffix, func(w http.ResponseWriter, _ *http.Request) { w.Write(sums) })
+	mux.HandleFunc("/desktop"+checksumSuffix+signatureSuffix, func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(ed25519.Sign(key, remoteMessage(signedURL, sums)))
+	})
+	return url, pubkey
+}
+
+// pinVersion sets the embedded Version for the test.
+func pinVersion(t *testing.T, v string) {
+	t.Helper()
+	old := Version
+	Version = v
+	t.Cleanup(func() { Version = old })
+}
+
+// launchRemote runs LaunchWithRemoteOverride and returns the path the started
+// process runs from.
+func launchRemote(t *testing.T, m *Manager, url string, pubkey ed25519.PublicKey) string {
+	t.Helper()
+	// the remote binary is the test binary, keep it from running the tests
+	t.Setenv(helperEnv, "echo-args")
+	p, err := m.LaunchWithRemoteOverride(context.Background(), url, pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	return p.Cmd().Path
+}
+
+func TestLaunchWithRemoteOverride(t *testing.T) {
+	requireRunnableBinary(t)
+	pinVersion(t, "1.0.0")
+	url, pubkey := remoteServer(t, remoteRelease{version: "1.0.1"})
+	log := &eventLog{}
+	m := newTestManager(t, WithLogger(log))
+
+	path := launchRemote(t, m, url, pubkey)
+	if want := filepath.Join(m.dir, remoteName); path != want {
+		t.Fatalf("launched %s, want the remote binary %s", path, want)
+	}
+	if !log.has("remote override") || log.has("remote override failed") {
+		t.Fatalf("events %v, want a successful remote override", log.events)
+	}
+}
+
+func TestLaunchWithRemoteOverrideFallsBack(t *testing.T) {
+	requireRunnableBinary(t)
+	pinVersion(t, "1.2.0")
+	tests := []struct {
+		name string
+		rel  remoteRelease
+		// breakLaunch changes the url or key launched with
+		breakLaunch func(url string, pubkey ed25519.PublicKey) (string, ed25519.PublicKey)
+	}{
+		{name: "bad signature", rel: remoteRelease{version: "1.3.0"}, breakLaunch: func(url string, _ ed25519.PublicKey) (string, ed25519.PublicKey) {
+			other, _, _ := ed25519.GenerateKey(rand.Reader)
+			return url, other
+		}},
+		// correctly signed, but for another binary
+		{name: "checksum mismatch", rel: remoteRelease{
+			sums: append(sidecar(strings.Repeat("0", 64), "desktop"), "version 1.3.0\n"...),
+		}},
+		// an old release with a valid signature, replayed
+		{name: "downgrade", rel: remoteRelease{version: "1.1.9"}},
+		{name: "same version", rel: remoteRelease{version: "1.2.0"}},
+		{name: "prerelease of the embedded version", rel: remoteRelease{version: "1.2.0-rc.1"}},
+		// a valid signature made for a release published somewhere else
+		{name: "signed for another url", rel: remoteRelease{version: "1.3.0", signedURL: "https://updates.example.com/desktop"}},
+		{name: "no version", rel: remoteRelease{sums: sidecar(strings.Repeat("0", 64), "desktop")}},
+		{name: "network failure", rel: remoteRelease{version: "1.3.0"}, breakLaunch: func(_ string, pubkey ed25519.PublicKey) (string, ed25519.PublicKey) {
+			srv := httptest.NewServer(http.NotFoundHandler())
+			srv.Close()
+			return srv.URL + "/desktop", pubkey
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, pubkey := remoteServer(t, tt.rel)
+			if tt.breakLaunch != nil {
+				url, pubkey = tt.breakLaunch(url, pubkey)
+			}
+			log := &eventLog{}
+			m := newTestManager(t, WithLogger(log))
+
+			path := launchRemote(t, m, url, pubkey)
+			if want := filepath.Join(m.dir, binaryName); path != want {
+				t.Fatalf("launched %s, want the embedded binary %s", path, want)
+			}
+			if !log.has("remote override failed") || log.has("remote override") {
+				t.Fatalf("events %v, want a failed remote override", log.events)
+			}
+			if _, err := os.Stat(filepath.Join(m.dir, remoteName)); err == nil {
+				t.Fatal("rejected remote binary was kept")
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.10", "1.2.9", 1},
+		{"1.2", "1.2.1", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.2.0-rc.1", "1.2.0", -1},
+		{"1.2.0-rc.2", "1.2.0-rc.1", 1},
+		{"1.2.0+build.5", "1.2.0", 0},
+	}
+	for _, tt := range tests {
+		if got, err := compareVersions(tt.a, tt.b); err != nil || got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, %v; want %d", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+	for _, v := range []string{"dev", "", "1..2", "1.x"} {
+		if _, err := compareVersions(v, "1.0.0"); err == nil {
+			t.Errorf("compareVersions accepted %q", v)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ExtractResourcesOnLaunch makes Command and the Launch family extract the
+// auxiliary resources next to the binary before starting it. An ephemeral launch
+// then keeps its temp dir until the process exits, see LaunchEphemeral. A dev
+// override, see AllowDesktopPathOverride, skips the resources. Set it before launching.
+var ExtractResourcesOnLaunch bool
+
+// extractsResources reports whether launches extract the resources.
+func (m *Manager) extractsResources() bool {
+	return m.extractResourcesOnLaunch() && !m.overrideActive()
+}
+
+// Auxiliary files the desktop needs next to its binary: icons, default config,
+// license. Everything under bin/resources is bundled, paths are kept relative
+// to that dir.
+//
+//go:embed all:bin/resources
+var resourcesFS embed.FS
+
+const resourcesRoot = "bin/resources"
+
+// ExtractResources writes the bundled auxiliary files into dir, preserving their
+// paths relative to bin/resources. Every file is written atomically.
+func ExtractResources(dir string) error {
+	return extractResources(resourcesFS, dir)
+}
+
+// extractResources writes the resources under bin/resources in fsys into dir.
+func extractResources(fsys fs.FS, dir string) error {
+	files, err := resourceFiles(fsys)
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		data, err := fs.ReadFile(fsys, resourcesRoot+"/"+name)
+		if err != nil {
+			return fmt.Errorf("embedded: read resource %s: %w", name, err)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("embedded: create resource dir: %w", err)
+		}
+		if err := WriteFileAtomic(path, data, fileMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceFiles lists the resource files in fsys as slash-separated paths
+// relative to bin/resources. The .gitkeep placeholder is not a resource.
+func resourceFiles(fsys fs.FS) ([]string, error) {
+	var files []string
+	err := fs.WalkDir(fsys, resourcesRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() == ".gitkeep" {
+			return err
+		}
+		files = append(files, path[len(resourcesRoot)+1:])
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("embedded: list resources: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,138 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestExtractResources(t *testing.T) {
+	fixtures := fstest.MapFS{
+		resourcesRoot + "/.gitkeep":            {},
+		resourcesRoot + "/LICENSE":             {Data: []byte("GPL-3.0\n")},
+		resourcesRoot + "/icons/app.png":       {Data: []byte("\x89PNG fixture")},
+		resourcesRoot + "/config/default.toml": {Data: []byte("theme = \"dark\"\n")},
+	}
+	dir := t.TempDir()
+	if err := extractResources(fixtures, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		got = append(got, filepath.ToSlash(rel))
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if want := fixtures[resourcesRoot+"/"+filepath.ToSlash(rel)].Data; string(data) != string(want) {
+			t.Errorf("%s holds %q, want %q", rel, data, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"LICENSE", "config/default.toml", "icons/app.png"}
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("extracted %v, want %v", got, want)
+	}
+}
+
+func TestEphemeralKeepsResourcesUntilExit(t *testing.T) {
+	m := newTestManager(t, WithExtractResourcesOnLaunch(true))
+	stdin, w := io.Pipe()
+	opts := helperOptions("cat")
+	opts.Ephemeral, opts.Stdin = true, stdin
+
+	cleaned := make(chan struct{})
+	p, err := m.launch(context.Background(), testExecutable(t), func() { close(cleaned) }, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-cleaned:
+		t.Fatal("temp dir removed while the process still runs from it")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_ = w.Close()
+	<-p.Done()
+	select {
+	case <-cleaned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("temp dir not removed after the process exited")
+	}
+}
+
+func TestEphemeralRunsInLauncherDir(t *testing.T) {
+	m := newTestManager(t, WithExtractResourcesOnLaunch(false))
+	want, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// run a copy from a temp dir removed right after start, as LaunchEphemeral does
+	dir := t.TempDir()
+	path := filepath.Join(dir, binaryName)
+	f, err := os.Open(testExecutable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeAtomic(path, f, binaryMode)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	opts := helperOptions("pwd")
+	opts.Ephemeral, opts.Stdout, opts.Stderr = true, &stdout, &stderr
+	p, err := m.launch(context.Background(), path, func() {}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatalf("helper failed: %v, stderr: %s", err, stderr.String())
+	}
+	if got := strings.TrimSpace(stdout.String()); !samePath(got, want) {
+		t.Fatalf("ephemeral process runs in %s, want the launcher's %s", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// LaunchWithRetry is Launch that retries extraction and start up to attempts
+// times, for transient failures such as a full disk or antivirus holding a lock.
+// Every attempt goes through LaunchWithOptions and re-hashes the cached copy
+// against the embedded checksum, even with VerifyCachedBeforeLaunch off, so a
+// corrupted copy is extracted again before it's started.
+// The delay between attempts starts at backoff and doubles each time. On failure
+// the error of the last attempt is returned, wrapped with the attempt count.
+func LaunchWithRetry(ctx context.Context, attempts int, backoff time.Duration, args ...string) (*Process, error) {
+	return defaultManager.LaunchWithRetry(ctx, attempts, backoff, args...)
+}
+
+// LaunchWithRetry is the package-level LaunchWithRetry using the Manager's settings.
+func (m *Manager) LaunchWithRetry(ctx context.Context, attempts int, backoff time.Duration, args ...string) (*Process, error) {
+	attempts = max(attempts, 1)
+	delay := backoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var p *Process
+		if p, err = m.launchWithOptions(ctx, LaunchOptions{Args: args}, true); err == nil {
+			return p, nil
+		}
+		if attempt >= attempts {
+			return nil, fmt.Errorf("embedded: launch failed after %d attempts: %w", attempt, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("embedded: launch canceled after %d attempts: %w (last error: %v)", attempt, ctx.Err(), err)
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// verifyFile hashes the file at path and compares it with the expected hex SHA-256.
+func verifyFile(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("embedded: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("embedded: hash %s: %w", path, err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("embedded: %s checksum mismatch: expected %s, actual %s", path, expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errDiskFull = errors.New("disk full")
+
+// failingWriter fails once it has accepted left bytes.
+type failingWriter struct {
+	w    io.Writer
+	left int
+}
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > fw.left {
+		n, _ := fw.w.Write(p[:fw.left])
+		fw.left = 0
+		return n, errDiskFull
+	}
+	fw.left -= len(p)
+	return fw.w.Write(p)
+}
+
+// failWrites makes the next n atomic writes fail halfway through.
+func failWrites(t *testing.T, n int) {
+	t.Helper()
+	t.Cleanup(func() { copyToTemp = io.Copy })
+	copyToTemp = func(dst io.Writer, src io.Reader) (int64, error) {
+		if n > 0 {
+			n--
+			dst = &failingWriter{w: dst, left: 1 << 10}
+		}
+		return io.Copy(dst, src)
+	}
+}
+
+func TestLaunchWithRetryRecovers(t *testing.T) {
+	requireRunnableBinary(t)
+	m := newTestManager(t)
+	failWrites(t, 2)
+
+	p, err := m.LaunchWithRetry(context.Background(), 3, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if s := m.Stats(); s.ExtractionFailures != 2 || s.Extractions != 1 || s.Launches != 1 {
+		t.Fatalf("stats %+v, want 2 failed extractions, then 1 extraction and 1 launch", s)
+	}
+}
+
+func TestLaunchWithRetryGivesUp(t *testing.T) {
+	requireRunnableBinary(t)
+	m := newTestManager(t)
+	failWrites(t, 3)
+
+	_, err := m.LaunchWithRetry(context.Background(), 2, time.Millisecond)
+	if !errors.Is(err, errDiskFull) {
+		t.Fatalf("err = %v, want the write error of the last attempt", err)
+	}
+	if !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Fatalf("err = %v, doesn't name the attempt count", err)
+	}
+	if s := m.Stats(); s.ExtractionFailures != 2 || s.Launches != 0 {
+		t.Fatalf("stats %+v, want 2 failed extractions and no launch", s)
+	}
+}
+
+func TestLaunchWithRetryCanceled(t *testing.T) {
+	requireRunnableBinary(t)
+	m := newTestManager(t)
+	failWrites(t, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := m.LaunchWithRetry(ctx, 5, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled during the backoff", err)
+	}
+}
+
+func TestLaunchWithRetryReverifies(t *testing.T) {
+	requireRunnableBinary(t)
+	m := newTestManager(t, WithVerifyCachedBeforeLaunch(false))
+	path, err := m.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	corruptInPlace(t, path)
+
+	p, err := m.LaunchWithRetry(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = p.Wait()
+	if err := verifyFile(path, Checksum()); err != nil {
+		t.Fatalf("corrupted copy was launched: %v", err)
+	}
+}
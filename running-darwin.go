@@ -0,0 +1,56 @@
+//go:build darwin
+// +build darwin
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// processImage returns the path of the executable the process with pid runs.
+// kern.procargs2 starts with argc followed by the NUL terminated exec path.
+func processImage(pid int) (string, error) {
+	buf, err := unix.SysctlRaw("kern.procargs2", pid)
+	if errors.Is(err, unix.ESRCH) || errors.Is(err, unix.EINVAL) {
+		return "", errProcessGone
+	}
+	if errors.Is(err, os.ErrPermission) {
+		return "", errForeignProcess
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(buf) < 4 {
+		return "", errors.New("embedded: short kern.procargs2")
+	}
+	path, _, _ := bytes.Cut(buf[4:], []byte{0})
+	return string(path), nil
+}
@@ -0,0 +1,146 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const pidFileName = ".warpnet-desktop.pid"
+
+// SingleInstance makes every launch, from Launch and LaunchWithOptions to
+// LaunchWithRetry, LaunchPTY and LaunchInMemory, fail with ErrAlreadyRunning
+// while IsRunning reports a desktop started by an earlier launch. The check isn't
+// atomic with the start, so two launchers racing each other may both succeed.
+var SingleInstance = false
+
+// ErrAlreadyRunning is returned by launches while SingleInstance is set and the
+// desktop is already running.
+var ErrAlreadyRunning = errors.New("embedded: desktop is already running")
+
+// IsRunning reports whether the desktop started by the last launch is still
+// running, and its PID if so. The PID is read from a pidfile every launch but
+// an ephemeral one writes into ExtractDir. It only counts as running if the process with that PID is alive and
+// runs the binary the pidfile records, so a PID reused by an unrelated process
+// isn't mistaken for the desktop. A missing or stale pidfile means not running,
+// and so does one naming a process the caller isn't allowed to inspect.
+func IsRunning() (bool, int, error) {
+	return defaultManager.IsRunning()
+}
+
+// IsRunning is the package-level IsRunning for the Manager's extraction dir.
+func (m *Manager) IsRunning() (bool, int, error) {
+	dir, err := m.resolveDir()
+	if err != nil {
+		return false, 0, err
+	}
+	pid, path, err := readPIDFile(filepath.Join(dir, pidFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+
+	image, err := processImage(pid)
+	if errors.Is(err, errProcessGone) || errors.Is(err, errForeignProcess) {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, fmt.Errorf("embedded: inspect pid %d: %w", pid, err)
+	}
+	if !samePath(image, path) {
+		return false, 0, nil
+	}
+	return true, pid, nil
+}
+
+// errProcessGone is returned by processImage when no process has the PID.
+var errProcessGone = errors.New("embedded: no such process")
+
+// errForeignProcess is returned by processImage when the process with the PID
+// can't be inspected, e.g. because it belongs to another user. The desktop this
+// package started runs as the caller, so such a process is never ours.
+var errForeignProcess = errors.New("embedded: process not inspectable")
+
+// writePIDFile records p as the running desktop started from path and removes
+// the record again once p exits.
+func (m *Manager) writePIDFile(p *Process, path string) error {
+	dir, err := m.ExtractDir()
+	if err != nil {
+		return err
+	}
+	pidFile := filepath.Join(dir, pidFileName)
+	if err := checkInside(dir, pidFile); err != nil {
+		return err
+	}
+	data := fmt.Sprintf("%d\n%s\n", p.PID(), path)
+	if err := WriteFileAtomic(pidFile, []byte(data), fileMode); err != nil {
+		return err
+	}
+
+	go func() {
+		<-p.Done()
+		// a later launch may have replaced the record, leave that one alone
+		if pid, _, err := readPIDFile(pidFile); err == nil && pid == p.PID() {
+			_ = os.Remove(pidFile)
+		}
+	}()
+	return nil
+}
+
+// readPIDFile parses a pidfile written by writePIDFile.
+func readPIDFile(name string) (pid int, path string, err error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return 0, "", err
+	}
+	lines := strings.SplitN(strings.TrimRight(string(data), "\n"), "\n", 2)
+	if len(lines) != 2 || lines[1] == "" {
+		return 0, "", fmt.Errorf("embedded: malformed pidfile %s", name)
+	}
+	pid, err = strconv.Atoi(lines[0])
+	if err != nil || pid <= 0 {
+		return 0, "", fmt.Errorf("embedded: malformed pidfile %s", name)
+	}
+	return pid, lines[1], nil
+}
+
+// samePath reports whether a and b name the same file, resolving symlinks where
+// the file still exists.
+func samePath(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
@@ -0,0 +1,121 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeTestPIDFile(t *testing.T, m *Manager, pid int, path string) {
+	t.Helper()
+	data := fmt.Sprintf("%d\n%s\n", pid, path)
+	if err := os.WriteFile(filepath.Join(m.dir, pidFileName), []byte(data), fileMode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func requireProcessImage(t *testing.T) {
+	t.Helper()
+	if _, err := processImage(os.Getpid()); errors.Is(err, errors.ErrUnsupported) {
+		t.Skip(err)
+	}
+}
+
+func TestIsRunningNoPIDFile(t *testing.T) {
+	running, pid, err := newTestManager(t).IsRunning()
+	if err != nil || running || pid != 0 {
+		t.Fatalf("IsRunning = %t, %d, %v; want not running", running, pid, err)
+	}
+}
+
+func TestIsRunningStalePIDFile(t *testing.T) {
+	requireProcessImage(t)
+	exe := testExecutable(t)
+	cmd := helperCommand(t, "echo-args")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestManager(t)
+	writeTestPIDFile(t, m, cmd.Process.Pid, exe)
+	running, _, err := m.IsRunning()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running {
+		t.Fatalf("pid %d exited but counts as running", cmd.Process.Pid)
+	}
+}
+
+func TestIsRunningChecksImage(t *testing.T) {
+	requireProcessImage(t)
+	exe := testExecutable(t)
+	m := newTestManager(t)
+
+	// this test process is alive, so only the recorded binary decides
+	writeTestPIDFile(t, m, os.Getpid(), filepath.Join(t.TempDir(), binaryName))
+	if running, _, err := m.IsRunning(); err != nil || running {
+		t.Fatalf("IsRunning = %t, %v for a reused pid; want not running", running, err)
+	}
+	writeTestPIDFile(t, m, os.Getpid(), exe)
+	running, pid, err := m.IsRunning()
+	if err != nil || !running || pid != os.Getpid() {
+		t.Fatalf("IsRunning = %t, %d, %v; want running as %d", running, pid, err, os.Getpid())
+	}
+}
+
+func TestIsRunningForeignProcess(t *testing.T) {
+	requireProcessImage(t)
+	// init, or System on Windows, which only root or an administrator may inspect
+	pid := 1
+	if runtime.GOOS == "windows" {
+		pid = 4
+	}
+	if _, err := processImage(pid); !errors.Is(err, errForeignProcess) {
+		t.Logf("pid %d is inspectable (%v), only the image check is exercised", pid, err)
+	}
+
+	m := newTestManager(t)
+	writeTestPIDFile(t, m, pid, filepath.Join(t.TempDir(), binaryName))
+	running, _, err := m.IsRunning()
+	if err != nil || running {
+		t.Fatalf("IsRunning = %t, %v for another user's pid; want not running", running, err)
+	}
+}
+
+func TestIsRunningMalformedPIDFile(t *testing.T) {
+	m := newTestManager(t)
+	if err := os.WriteFile(filepath.Join(m.dir, pidFileName), []byte("not a pid\n"), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.IsRunning(); err == nil {
+		t.Fatal("malformed pidfile read without error")
+	}
+}
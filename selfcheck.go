@@ -0,0 +1,67 @@
+//go:build warpnet_selfcheck
+// +build warpnet_selfcheck
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ExpectedSHA256 is the hex SHA-256 the embedded desktop binary must have in
+// builds with the warpnet_selfcheck tag. It is injected at build time, usually
+// from the sidecar make build writes:
+//
+//	go build -tags warpnet_selfcheck -ldflags "-X github.com/Warp-net/warpnet-desktop.ExpectedSHA256=<sha256>"
+//
+// Without the tag neither the variable nor the check is compiled in.
+var ExpectedSHA256 string
+
+// init aborts startup if the embedded binary isn't the one the build was pinned
+// to, e.g. because the payload of the wrapper was swapped.
+func init() {
+	if err := selfCheck(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+}
+
+func selfCheck() error {
+	if ExpectedSHA256 == "" {
+		return errors.New("embedded: self-check: built with warpnet_selfcheck but ExpectedSHA256 wasn't injected")
+	}
+	actual, err := checksum()
+	if err != nil {
+		return fmt.Errorf("embedded: self-check: %w", err)
+	}
+	if expected := strings.ToLower(ExpectedSHA256); actual != expected {
+		return fmt.Errorf("embedded: self-check: embedded desktop binary checksum mismatch: expected %s, actual %s", expected, actual)
+	}
+	return nil
+}
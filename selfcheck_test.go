@@ -0,0 +1,99 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// buildSelfCheck compiles the package tests with the warpnet_selfcheck tag and
+// ExpectedSHA256 set to expected, and returns the binary.
+func buildSelfCheck(t *testing.T, expected string) string {
+	t.Helper()
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not available")
+	}
+	out := filepath.Join(t.TempDir(), "selfcheck.test")
+	if runtime.GOOS == "windows" {
+		out += ".exe"
+	}
+	cmd := exec.Command(goTool, "test", "-c", "-o", out, "-tags", "warpnet_selfcheck",
+		"-ldflags", "-X github.com/Warp-net/warpnet-desktop.ExpectedSHA256="+expected, ".")
+	if msg, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("build with warpnet_selfcheck: %v\n%s", err, msg)
+	}
+	return out
+}
+
+// runSelfCheck starts the binary without running any test, so only init runs.
+func runSelfCheck(t *testing.T, bin string) (code int, stderr string) {
+	t.Helper()
+	var buf bytes.Buffer
+	cmd := exec.Command(bin, "-test.run=^$")
+	cmd.Stderr = &buf
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		t.Fatal(err)
+	}
+	return cmd.ProcessState.ExitCode(), buf.String()
+}
+
+func TestSelfCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the package with the warpnet_selfcheck tag")
+	}
+	requireBinary(t)
+	testExecutable(t)
+
+	tests := []struct {
+		name, expected string
+		code           int
+		message        string
+	}{
+		{"pinned", Checksum(), 0, ""},
+		{"pinned uppercase", strings.ToUpper(Checksum()), 0, ""},
+		{"wrong", strings.Repeat("0", 64), 2, "embedded: self-check: embedded desktop binary checksum mismatch: expected " + strings.Repeat("0", 64)},
+		{"not injected", "", 2, "ExpectedSHA256 wasn't injected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stderr := runSelfCheck(t, buildSelfCheck(t, tt.expected))
+			if code != tt.code {
+				t.Fatalf("exit code = %d, want %d, stderr: %s", code, tt.code, stderr)
+			}
+			if !strings.Contains(stderr, tt.message) {
+				t.Fatalf("stderr = %q, want it to contain %q", stderr, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"fmt"
+)
+
+// SelfTestArgs are the args SelfTest launches the desktop with, see WithSelfTestArgs.
+var SelfTestArgs = []string{"--selftest"}
+
+// SelfTest is a go/no-go check for installers and CI: it launches the desktop
+// with SelfTestArgs, waits for probe to pass as LaunchAndWaitReady does, then
+// shuts the desktop down gracefully. It returns nil only if the desktop became
+// ready, didn't exit with a non-zero code on its own and stopped within
+// DefaultShutdownGrace, or the grace given WithShutdownGrace.
+func SelfTest(ctx context.Context, probe func(context.Context) error) error {
+	return defaultManager.SelfTest(ctx, probe)
+}
+
+// SelfTest is the package-level SelfTest using the Manager's settings.
+func (m *Manager) SelfTest(ctx context.Context, probe func(context.Context) error) error {
+	p, err := m.Launch(ctx, m.selfTestArguments()...)
+	if err != nil {
+		return fmt.Errorf("embedded: selftest: %w", err)
+	}
+
+	// stop probing as soon as the desktop exits
+	readyCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.Done():
+			cancel()
+		case <-readyCtx.Done():
+		}
+	}()
+
+	if err := m.waitReady(readyCtx, probe); err != nil {
+		select {
+		case <-p.Done():
+			return fmt.Errorf("embedded: selftest: desktop exited with code %d before it was ready", p.ExitCode())
+		default:
+		}
+		_ = p.Kill()
+		return fmt.Errorf("embedded: selftest: %w", err)
+	}
+
+	select {
+	case <-p.Done():
+		if code := p.ExitCode(); code != 0 {
+			return fmt.Errorf("embedded: selftest: desktop exited with code %d", code)
+		}
+		return nil
+	default:
+	}
+	if err := p.Shutdown(m.shutdownGrace()); err != nil {
+		return fmt.Errorf("embedded: selftest: %w", err)
+	}
+	return nil
+}
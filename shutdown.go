@@ -0,0 +1,75 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// DefaultShutdownGrace is how long a process stopped by context cancellation
+// gets to exit on its own before it is killed, unless the Manager is given
+// WithShutdownGrace.
+const DefaultShutdownGrace = 5 * time.Second
+
+// Shutdown asks the process started by cmd to exit (SIGTERM on Unix, a console
+// control event on Windows), waits up to grace and kills it if it's still running.
+// If the graceful signal can't be delivered the process is killed right away.
+//
+// Shutdown waits for the process itself, so the caller must not call cmd.Wait.
+// It returns nil if the process exited within grace. For a Process returned by
+// the Launch family use Process.Shutdown instead.
+func Shutdown(cmd *exec.Cmd, grace time.Duration) error {
+	if cmd.Process == nil {
+		return errors.New("embedded: shutdown: process is not started")
+	}
+	return newProcess(cmd, logEvent).Shutdown(grace)
+}
+
+// waitErr drops the exit status of a stopped process and reports only failures
+// of Wait itself.
+func waitErr(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil
+	}
+	return err
+}
+
+// setCancel makes context cancellation take the Shutdown path: the process is
+// asked to exit and killed after grace.
+func setCancel(cmd *exec.Cmd, grace time.Duration) {
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		err := terminate(cmd.Process)
+		if err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return cmd.Process.Kill()
+		}
+		return err
+	}
+	cmd.WaitDelay = grace
+}
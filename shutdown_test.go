@@ -0,0 +1,141 @@
+//go:build unix
+// +build unix
+
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func init() {
+	helpers["trap-term"] = trapTerm
+}
+
+// trapTerm reports "ready" once it handles SIGTERM and "graceful" before it
+// exits with status 0 on it. Without the signal it runs until killed.
+func trapTerm() int {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM)
+	fmt.Println("ready")
+	<-signals
+	fmt.Println("graceful")
+	return 0
+}
+
+// readLines returns a reader of the pipe w writes into; w goes to the child.
+func readLines(t *testing.T) (r *bufio.Reader, w *os.File) {
+	t.Helper()
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pr.Close() })
+	return bufio.NewReader(pr), pw
+}
+
+func expectLine(t *testing.T, r *bufio.Reader, want string) {
+	t.Helper()
+	line, err := r.ReadString('\n')
+	if err != nil {
+		t.Fatalf("reading %q: %v", want, err)
+	}
+	if got := strings.TrimSpace(line); got != want {
+		t.Fatalf("child printed %q, want %q", got, want)
+	}
+}
+
+func TestShutdownGraceful(t *testing.T) {
+	r, w := readLines(t)
+	cmd := helperCommand(t, "trap-term")
+	cmd.Stdout = w
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	expectLine(t, r, "ready")
+
+	if err := Shutdown(cmd, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	expectLine(t, r, "graceful")
+	if code := cmd.ProcessState.ExitCode(); code != 0 {
+		t.Fatalf("exit code %d, want 0 from the SIGTERM handler", code)
+	}
+}
+
+func TestShutdownKillsAfterGrace(t *testing.T) {
+	r, w := readLines(t)
+	cmd := helperCommand(t, "trap-term")
+	cmd.Stdout = w
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	expectLine(t, r, "ready")
+	// a stopped process can't handle SIGTERM, only SIGKILL ends it
+	if err := cmd.Process.Signal(syscall.SIGSTOP); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Shutdown(cmd, 100*time.Millisecond); err == nil {
+		t.Fatal("Shutdown of a stopped process returned nil, want the kill error")
+	}
+	if status := cmd.ProcessState.Sys().(syscall.WaitStatus); !status.Signaled() || status.Signal() != syscall.SIGKILL {
+		t.Fatalf("process exited with %v, want SIGKILL", cmd.ProcessState)
+	}
+}
+
+func TestLaunchCancelShutsDownGracefully(t *testing.T) {
+	m := helperManager(t)
+	r, w := readLines(t)
+	opts := helperOptions("trap-term")
+	opts.Stdout = w
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, err := m.LaunchWithOptions(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	expectLine(t, r, "ready")
+
+	cancel()
+	<-p.Done()
+	expectLine(t, r, "graceful")
+	if code := p.ExitCode(); code != 0 {
+		t.Fatalf("exit code %d, want 0 from the SIGTERM handler", code)
+	}
+}
@@ -0,0 +1,111 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// StatsSnapshot is a point-in-time copy of the counters a Manager keeps while it
+// extracts and launches the desktop, see Stats.
+type StatsSnapshot struct {
+	// Extractions counts binaries written to disk, by EnsureExtracted after a
+	// cache miss, ExtractTo, Update and ephemeral launches alike, and
+	// ExtractionFailures the attempts that failed.
+	Extractions        int64
+	ExtractionFailures int64
+	// CacheHits counts extractions skipped because an up to date copy was there.
+	CacheHits int64
+	// BytesWritten is the size of all extracted binaries put together.
+	BytesWritten int64
+	// ExtractionTime is the time spent in extractions, failed ones included.
+	ExtractionTime time.Duration
+
+	// Launches counts started processes, whichever launch started them, and
+	// LaunchFailures the starts that failed.
+	Launches       int64
+	LaunchFailures int64
+	// Exits counts launched processes that have exited, and Uptime is how long
+	// they ran put together.
+	Exits  int64
+	Uptime time.Duration
+	// Crashes counts supervised runs that exited with a non-zero code or a signal.
+	Crashes int64
+
+	// Decompressions counts decompressions of the binary into memory by this
+	// process, see GetDesktopEmbedded. It isn't specific to a Manager.
+	Decompressions int64
+}
+
+// stats holds the counters of a Manager.
+type stats struct {
+	extractions        atomic.Int64
+	extractionFailures atomic.Int64
+	cacheHits          atomic.Int64
+	bytesWritten       atomic.Int64
+	extractionTime     atomic.Int64
+	launches           atomic.Int64
+	launchFailures     atomic.Int64
+	exits              atomic.Int64
+	uptime             atomic.Int64
+	crashes            atomic.Int64
+}
+
+// watch counts the launch of p and, once it exits, its uptime.
+func (m *Manager) watch(p *Process, started time.Time) {
+	m.stats.launches.Add(1)
+	go func() {
+		<-p.Done()
+		m.stats.exits.Add(1)
+		m.stats.uptime.Add(int64(time.Since(started)))
+	}()
+}
+
+// Stats returns the counters of the package-level functions. To publish them,
+// e.g. with expvar, no dependency is needed:
+//
+//	expvar.Publish("warpnet_desktop", expvar.Func(func() any { return embedded.Stats() }))
+func Stats() StatsSnapshot {
+	return defaultManager.Stats()
+}
+
+// Stats returns the counters of the Manager.
+func (m *Manager) Stats() StatsSnapshot {
+	s := &m.stats
+	return StatsSnapshot{
+		Extractions:        s.extractions.Load(),
+		ExtractionFailures: s.extractionFailures.Load(),
+		CacheHits:          s.cacheHits.Load(),
+		BytesWritten:       s.bytesWritten.Load(),
+		ExtractionTime:     time.Duration(s.extractionTime.Load()),
+		Launches:           s.launches.Load(),
+		LaunchFailures:     s.launchFailures.Load(),
+		Exits:              s.exits.Load(),
+		Uptime:             time.Duration(s.uptime.Load()),
+		Crashes:            s.crashes.Load(),
+		Decompressions:     decompressions.Load(),
+	}
+}
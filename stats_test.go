@@ -0,0 +1,69 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsExtraction(t *testing.T) {
+	requireBinary(t)
+	m := newTestManager(t, WithVerifyCachedBeforeLaunch(false))
+	if s := m.Stats(); s.Extractions != 0 || s.CacheHits != 0 || s.BytesWritten != 0 {
+		t.Fatalf("fresh manager stats %+v, want zero counters", s)
+	}
+
+	if _, err := m.Extract(); err != nil {
+		t.Fatal(err)
+	}
+	miss := m.Stats()
+	if miss.Extractions != 1 || miss.CacheHits != 0 || miss.BytesWritten != Size() || miss.ExtractionTime <= 0 {
+		t.Fatalf("after a miss: %+v, want one extraction of %d bytes", miss, Size())
+	}
+
+	if _, err := m.Extract(); err != nil {
+		t.Fatal(err)
+	}
+	hit := m.Stats()
+	if hit.Extractions != 1 || hit.CacheHits != 1 || hit.BytesWritten != miss.BytesWritten || hit.ExtractionFailures != 0 {
+		t.Fatalf("after a hit: %+v, want one extraction and one cache hit", hit)
+	}
+}
+
+func TestStatsLaunch(t *testing.T) {
+	m := helperManager(t)
+	runHelper(t, m, helperOptions("echo-args"))
+
+	// the exit is counted right after Done is closed
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Stats().Exits == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s := m.Stats()
+	if s.Launches != 1 || s.LaunchFailures != 0 || s.Exits != 1 || s.Uptime <= 0 {
+		t.Fatalf("stats %+v, want one launch that exited", s)
+	}
+}
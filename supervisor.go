@@ -0,0 +1,187 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBackoffBase  = time.Second
+	DefaultBackoffMax   = time.Minute
+	DefaultBackoffReset = time.Minute
+)
+
+// Supervisor keeps the desktop running: it launches the binary and relaunches it
+// with exponential backoff whenever it exits, until the context passed to Start
+// is canceled. Zero-valued fields fall back to their defaults.
+type Supervisor struct {
+	// Args are passed to every launch.
+	Args []string
+	// BackoffBase is the delay before the first restart, doubled after every
+	// consecutive restart. Defaults to DefaultBackoffBase.
+	BackoffBase time.Duration
+	// BackoffMax caps the delay between restarts. Defaults to DefaultBackoffMax.
+	BackoffMax time.Duration
+	// BackoffReset is how long the process has to stay up for the delay to go
+	// back to BackoffBase. Defaults to DefaultBackoffReset.
+	BackoffReset time.Duration
+	// RestartOnSuccess restarts the process after a clean exit (code 0) too.
+	// By default a clean exit stops supervision.
+	RestartOnSuccess bool
+	// Manager launches the process and receives its events and Stats. Defaults
+	// to the one behind the package-level functions.
+	Manager *Manager
+
+	mx        sync.Mutex
+	started   bool
+	restarts  int
+	exitCodes []int
+	err       error
+	done      chan struct{}
+}
+
+// Start launches the desktop and supervises it in the background. It returns
+// an error if the supervisor was already started. Use Wait to block until
+// supervision stops.
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.started {
+		return errors.New("embedded: supervisor is already started")
+	}
+	s.started = true
+	s.done = make(chan struct{})
+
+	go s.run(ctx)
+	return nil
+}
+
+// Wait blocks until supervision stops: ctx is canceled, the process exits
+// cleanly without RestartOnSuccess, or it can't be launched. It returns the
+// launch error in the latter case and nil otherwise.
+func (s *Supervisor) Wait() error {
+	s.mx.Lock()
+	done := s.done
+	s.mx.Unlock()
+	if done == nil {
+		return errors.New("embedded: supervisor is not started")
+	}
+	<-done
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.err
+}
+
+// Restarts returns how many times the process was relaunched.
+func (s *Supervisor) Restarts() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.restarts
+}
+
+// ExitCodes returns the exit codes of all runs so far, oldest first.
+// A process killed by a signal reports -1.
+func (s *Supervisor) ExitCodes() []int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return append([]int(nil), s.exitCodes...)
+}
+
+func (s *Supervisor) run(ctx context.Context) {
+	defer close(s.done)
+
+	m := s.Manager
+	if m == nil {
+		m = defaultManager
+	}
+	base, limit, reset := s.backoff()
+	delay := base
+	for {
+		startedAt := time.Now()
+		p, err := m.Launch(ctx, s.Args...)
+		if err != nil {
+			m.log("supervisor stopped", "err", err)
+			s.mx.Lock()
+			s.err = err
+			s.mx.Unlock()
+			return
+		}
+		_ = p.Wait()
+		code := p.ExitCode()
+
+		s.mx.Lock()
+		s.exitCodes = append(s.exitCodes, code)
+		s.mx.Unlock()
+
+		if ctx.Err() != nil {
+			m.log("supervisor stopped", "err", ctx.Err())
+			return
+		}
+		if code != 0 {
+			m.stats.crashes.Add(1)
+		}
+		if code == 0 && !s.RestartOnSuccess {
+			m.log("supervisor stopped", "code", code)
+			return
+		}
+		if time.Since(startedAt) >= reset {
+			delay = base
+		}
+
+		m.log("restarting", "code", code, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			m.log("supervisor stopped", "err", ctx.Err())
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, limit)
+
+		s.mx.Lock()
+		s.restarts++
+		s.mx.Unlock()
+	}
+}
+
+func (s *Supervisor) backoff() (base, limit, reset time.Duration) {
+	base, limit, reset = s.BackoffBase, s.BackoffMax, s.BackoffReset
+	if base <= 0 {
+		base = DefaultBackoffBase
+	}
+	if limit <= 0 {
+		limit = DefaultBackoffMax
+	}
+	if reset <= 0 {
+		reset = DefaultBackoffReset
+	}
+	return base, limit, reset
+}
@@ -0,0 +1,200 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const versionPrefixLen = 12
+
+// Update installs the embedded desktop binary into dir for in-place updates while
+// an older version may still be running. The binary is written under a versioned
+// name carrying a prefix of its SHA-256, and the stable name used by
+// EnsureExtracted is switched to point at it, so the running process keeps its
+// old file. Versions other than the new one and the one the stable name pointed
+// at before the call are removed. The returned path is the versioned file.
+// An empty dir means ExtractDir.
+//
+// The stable name is a symlink swapped in with an atomic rename. Windows only
+// allows symlinks with Developer Mode or the SeCreateSymbolicLinkPrivilege, and
+// junctions only work for directories, so where a symlink can't be created the
+// stable name becomes a hard link (or, failing that, a copy) renamed into place.
+// If the stable file is locked because it's running, it's renamed aside first,
+// which Windows permits for running executables.
+func Update(dir string) (path string, err error) {
+	return defaultManager.Update(dir)
+}
+
+// Update is the package-level Update; an empty dir means the Manager's extraction dir.
+func (m *Manager) Update(dir string) (path string, err error) {
+	sum, err := checksum()
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		if dir, err = m.ExtractDir(); err != nil {
+			return "", err
+		}
+	}
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = fmt.Errorf("embedded: unlock extraction dir: %w", unlockErr)
+		}
+	}()
+
+	stable := filepath.Join(dir, binaryName)
+	previous := currentVersion(stable)
+
+	name := versionedName(sum)
+	path = filepath.Join(dir, name)
+	for _, p := range []string{stable, path} {
+		if err := checkInside(dir, p); err != nil {
+			return "", err
+		}
+	}
+	if !isExtracted(path, sum) {
+		if err := m.extractFile(path, nil, ""); err != nil {
+			return "", err
+		}
+		if err := WriteFileAtomic(path+checksumSuffix, sidecar(sum, name), fileMode); err != nil {
+			return "", err
+		}
+	}
+
+	if err := pointStable(stable, path); err != nil {
+		return "", err
+	}
+	if err := WriteFileAtomic(stable+checksumSuffix, sidecar(sum, binaryName), fileMode); err != nil {
+		return "", err
+	}
+
+	removeVersions(dir, name, previous)
+	return path, nil
+}
+
+// versionedName is binaryName with the SHA-256 prefix inserted before the extension.
+func versionedName(sum string) string {
+	ext := filepath.Ext(binaryName)
+	return strings.TrimSuffix(binaryName, ext) + "-" + sum[:versionPrefixLen] + ext
+}
+
+// isVersionedName reports whether name looks like a versionedName.
+func isVersionedName(name string) bool {
+	ext := filepath.Ext(binaryName)
+	base, ok := strings.CutPrefix(name, strings.TrimSuffix(binaryName, ext)+"-")
+	if !ok || !strings.HasSuffix(base, ext) {
+		return false
+	}
+	return isVersionPrefix(strings.TrimSuffix(base, ext))
+}
+
+// isVersionPrefix reports whether s is a SHA-256 prefix as used in version names.
+func isVersionPrefix(s string) bool {
+	return len(s) == versionPrefixLen && strings.Trim(s, "0123456789abcdef") == ""
+}
+
+// currentVersion returns the versioned file name the stable symlink points at,
+// or "" if it's not a symlink to a version.
+func currentVersion(stable string) string {
+	target, err := os.Readlink(stable)
+	if err != nil {
+		return ""
+	}
+	if name := filepath.Base(target); isVersionedName(name) {
+		return name
+	}
+	return ""
+}
+
+// pointStable makes the stable name resolve to the versioned file at path.
+func pointStable(stable, path string) error {
+	tmp := stable + ".link.tmp"
+	_ = os.Remove(tmp)
+
+	err := os.Symlink(filepath.Base(path), tmp)
+	if err != nil {
+		// symlinks not permitted, fall back to a hard link or a copy
+		if err = os.Link(path, tmp); err != nil {
+			err = copyFile(path, tmp)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("embedded: link %s: %w", binaryName, err)
+	}
+
+	// a running stable file that is locked gets moved aside
+	if err := replaceFile(tmp, stable); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeAtomic(dst, f, binaryMode)
+}
+
+// removeVersions deletes versioned binaries and their sidecars except keep.
+// Files that can't be removed, such as a version still running on Windows,
+// are left for the next call.
+func removeVersions(dir string, keep ...string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if isAsideName(name) {
+			// still locked on Windows while running, left for the next call
+			_ = os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		if !isVersionedName(name) || slices.Contains(keep, name) {
+			continue
+		}
+		for _, p := range []string{name, name + checksumSuffix} {
+			err := os.Remove(filepath.Join(dir, p))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				break
+			}
+		}
+	}
+}
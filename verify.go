@@ -0,0 +1,154 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	verifyOnce sync.Once
+	verifyErr  error
+
+	checksumOnce sync.Once
+	checksumHex  string
+	checksumErr  error
+)
+
+// Verify hashes the embedded desktop binary and compares it with the checksum
+// from the sha256 sidecar embedded next to it.
+func Verify() error {
+	verifyOnce.Do(func() {
+		verifyErr = verify()
+	})
+	return verifyErr
+}
+
+// GetDesktopEmbeddedVerified returns the desktop binary only if it passes Verify.
+func GetDesktopEmbeddedVerified() ([]byte, error) {
+	if err := Verify(); err != nil {
+		return nil, err
+	}
+	return GetDesktopEmbeddedErr()
+}
+
+func verify() error {
+	expected, err := expectedChecksum()
+	if err != nil {
+		return err
+	}
+	actual, err := checksum()
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("embedded: checksum mismatch: expected %s, actual %s", expected, actual)
+	}
+	return nil
+}
+
+// expectedChecksum returns the hash recorded in the embedded sha256 sidecar.
+func expectedChecksum() (string, error) {
+	a, err := assetFor(runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+	sidecar, err := a.sidecar()
+	if err != nil {
+		return "", fmt.Errorf("embedded: read %s: %w", a.sidecarName, err)
+	}
+	expected, err := parseChecksum(sidecar)
+	if err != nil {
+		return "", fmt.Errorf("embedded: %s: %w", a.sidecarName, err)
+	}
+	return expected, nil
+}
+
+// parseChecksum reads the hash from sha256sum output: the hex digest, optionally
+// followed by a two-space (text mode) or space-asterisk (binary mode) separated
+// file name. Only the first line is considered.
+func parseChecksum(sidecar []byte) (string, error) {
+	line, _, _ := strings.Cut(string(sidecar), "\n")
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", errors.New("malformed checksum sidecar: empty")
+	}
+	sum, name, hasName := strings.Cut(line, " ")
+	if hasName && !strings.HasPrefix(name, " ") && !strings.HasPrefix(name, "*") {
+		return "", fmt.Errorf("malformed checksum sidecar: unexpected separator in %q", line)
+	}
+	if len(sum) != sha256.Size*2 {
+		return "", fmt.Errorf("malformed checksum sidecar: digest %q is not %d hex chars", sum, sha256.Size*2)
+	}
+	if _, err := hex.DecodeString(sum); err != nil {
+		return "", fmt.Errorf("malformed checksum sidecar: digest %q is not hex", sum)
+	}
+	return strings.ToLower(sum), nil
+}
+
+// Checksum returns the lowercase hex SHA-256 of the embedded desktop binary, as
+// embedded rather than as expected by Verify, e.g. for --version output or crash
+// reports. It is computed once; it is empty if the binary can't be decompressed.
+func Checksum() string {
+	sum, _ := checksum()
+	return sum
+}
+
+// hashChunkSize is the buffer checksum streams the binary through.
+const hashChunkSize = 32 << 10
+
+// checksum returns the hex SHA-256 of the decompressed desktop binary. The
+// binary is hashed as it is decompressed, so only one chunk is held at a time.
+func checksum() (string, error) {
+	checksumOnce.Do(func() {
+		checksumHex, checksumErr = hashAsset(runtime.GOARCH)
+	})
+	return checksumHex, checksumErr
+}
+
+func hashAsset(goarch string) (string, error) {
+	a, err := assetFor(goarch)
+	if err != nil {
+		return "", err
+	}
+	r, err := a.open()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	h := sha256.New()
+	if _, err := io.CopyBuffer(h, r, make([]byte, hashChunkSize)); err != nil {
+		return "", fmt.Errorf("embedded: hash %s: %w", a.name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,139 @@
+/* License generated by licensor(https://github.com/Marvin9/licensor).
+
+Warpnet - Decentralized Social Network
+Copyright (C) 2025 Vadim Filin, https://github.com/Warp-net,
+<github.com.mecdy@passmail.net>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+WarpNet is provided “as is” without warranty of any kind, either expressed or implied.
+Use at your own risk. The maintainers shall not be liable for any damages or data loss
+resulting from the use or misuse of this software.
+*/
+
+package embedded
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testDigest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestParseChecksum(t *testing.T) {
+	tests := []struct {
+		name, sidecar string
+	}{
+		{"digest only", testDigest},
+		{"text mode name", testDigest + "  warpnet-desktop\n"},
+		{"binary mode name", testDigest + " *warpnet-desktop\n"},
+		{"trailing whitespace", testDigest + "  warpnet-desktop \t\r\n\n"},
+		{"uppercase", strings.ToUpper(testDigest) + "  warpnet-desktop\n"},
+		{"second line ignored", testDigest + "  warpnet-desktop\ngarbage\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := parseChecksum([]byte(tt.sidecar))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sum != testDigest {
+				t.Fatalf("sum = %s, want %s", sum, testDigest)
+			}
+		})
+	}
+}
+
+func TestParseChecksumMalformed(t *testing.T) {
+	tests := []struct {
+		name, sidecar, reason string
+	}{
+		{"empty", "", "empty"},
+		{"blank", " \n", "empty"},
+		{"short digest", testDigest[:63] + "  warpnet-desktop\n", "not 64 hex chars"},
+		{"not hex", "z" + testDigest[1:] + "\n", "not hex"},
+		{"single space separator", testDigest + " warpnet-desktop\n", "unexpected separator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseChecksum([]byte(tt.sidecar))
+			if err == nil {
+				t.Fatal("malformed sidecar parsed without error")
+			}
+			if msg := err.Error(); !strings.Contains(msg, "malformed checksum sidecar") || !strings.Contains(msg, tt.reason) {
+				t.Fatalf("error %q doesn't say the sidecar is malformed because %s", msg, tt.reason)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	requireBinary(t)
+	data, err := GetDesktopEmbeddedErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := sha256.Sum256(data)
+	want := hex.EncodeToString(raw[:])
+	if sum := Checksum(); sum != want {
+		t.Fatalf("Checksum = %s, want the sha256 of the binary %s", sum, want)
+	}
+	expected, err := expectedChecksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected != want {
+		t.Fatalf("sidecar has %s, want %s", expected, want)
+	}
+	if err := Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// BenchmarkVerifyHash compares the streaming hash Verify uses with reading the
+// whole binary into memory first; allocs/op and B/op show the difference.
+func BenchmarkVerifyHash(b *testing.B) {
+	requireBinary(b)
+	b.Run("streaming", func(b *testing.B) {
+		b.SetBytes(Size())
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := hashAsset(runtime.GOARCH); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("naive", func(b *testing.B) {
+		a := assets[runtime.GOARCH]
+		b.SetBytes(Size())
+		b.ReportAllocs()
+		for b.Loop() {
+			r, err := a.open()
+			if err != nil {
+				b.Fatal(err)
+			}
+			data, err := io.ReadAll(r)
+			r.Close()
+			if err != nil {
+				b.Fatal(err)
+			}
+			sum := sha256.Sum256(data)
+			_ = hex.EncodeToString(sum[:])
+		}
+	})
+}